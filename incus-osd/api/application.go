@@ -14,7 +14,10 @@ type ApplicationState struct {
 	FriendlyVersion   string     `json:"friendly_version"        yaml:"friendly_version"`
 	Version           string     `json:"version"                 yaml:"version"`
 	AvailableVersions []string   `json:"available_versions"      yaml:"available_versions"`
-	LastRestored      *time.Time `json:"last_restored,omitempty" yaml:"last_restored,omitempty"` // In system's timezone.
+	LastRestored      *time.Time `incusos:"-"                    json:"last_restored,omitempty" yaml:"last_restored,omitempty"` // In system's timezone. Not persisted, as older decoders can't parse it.
+	InstalledAt       *time.Time `json:"installed_at,omitempty"  yaml:"installed_at,omitempty"`                                 // In system's timezone.
+	Status            string     `incusos:"-"                    json:"status"                  yaml:"status"`
+	ListeningPorts    []int      `incusos:"-"                    json:"listening_ports"         yaml:"listening_ports"`
 }
 
 // Application represents the state and configuration of a generic application.
@@ -22,9 +22,10 @@ type SystemUpdateConfig struct {
 
 // SystemUpdateState holds information about the current update state.
 type SystemUpdateState struct {
-	LastCheck   time.Time `json:"last_check"   yaml:"last_check"` // In system's timezone.
-	Status      string    `json:"status"       yaml:"status"`
-	NeedsReboot bool      `json:"needs_reboot" yaml:"needs_reboot"`
+	LastCheck      time.Time `incusos:"-"            json:"last_check"      yaml:"last_check"` // In system's timezone. Not persisted, as older decoders can't parse it.
+	Status         string    `json:"status"          yaml:"status"`
+	NeedsReboot    bool      `json:"needs_reboot"    yaml:"needs_reboot"`
+	ImageSignature string    `json:"image_signature" yaml:"image_signature"` // Either "verified" or "failed" for the last applied OS update.
 }
 
 // SystemUpdateMaintenanceWindow defines a maintenance window for when it is acceptable to check for and apply updates.
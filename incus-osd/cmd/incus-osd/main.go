@@ -80,8 +80,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Get persistent state.
-	s, err := state.LoadOrCreate(filepath.Join(varPath, "state.txt"))
+	// Get persistent state, an alternate state file can be provided for testing or recovery.
+	statePath := os.Getenv("INCUSOS_STATE_PATH")
+	if statePath == "" {
+		statePath = filepath.Join(varPath, "state.txt")
+	}
+
+	s, err := state.LoadOrCreate(statePath)
 	if err != nil {
 		tui.EarlyError("unable to load state file: "+err.Error(), osName)
 		os.Exit(1)
@@ -122,6 +127,7 @@ func main() {
 		os.Exit(1)
 	}
 
+	secureBootWasDisabled := s.SecureBootDisabled
 	s.SecureBootDisabled = !sbEnabled
 
 	if s.SecureBootDisabled {
@@ -172,16 +178,41 @@ func main() {
 	}
 
 	go func() {
-		err := tuiApp.Run()
+		err := tuiApp.Run(ctx)
 		if err != nil {
 			tui.EarlyError(err.Error(), s.OS.Name)
 			os.Exit(1)
 		}
 	}()
 
-	// Prepare a logger.
-	logger := slog.New(tui.NewCustomTextHandler(tuiApp))
-	slog.SetDefault(logger)
+	// Prepare a logger, falling back to plain logging on stdout when no console is available.
+	consoleErr := tuiApp.ConsoleError()
+	if consoleErr != nil {
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
+		slog.WarnContext(ctx, "No console available, logging to stdout only", "err", consoleErr)
+	} else {
+		// Also keep a copy of the logs on disk.
+		tuiApp.SetLogFile("/var/log/incus-os/incus-osd.log")
+
+		slog.SetDefault(slog.New(tui.NewCustomTextHandler(tuiApp)))
+	}
+
+	// Offer the setup wizard on first boot when no network or applications seed was provided.
+	if consoleErr == nil && !s.OS.SuccessfulBoot && !s.ShouldPerformInstall && s.System.Network.Config == nil && !seed.Exists("network") && !seed.Exists("applications") {
+		err = tuiApp.RunSetupWizard(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Setup wizard failed, using the default configuration", "err", err)
+		}
+	}
+
+	// Flag any change of the Secure Boot state since the last boot.
+	if s.OS.SuccessfulBoot && s.SecureBootDisabled != secureBootWasDisabled {
+		if s.SecureBootDisabled {
+			tuiApp.AddSecurityEvent("Secure Boot has been disabled since the last boot")
+		} else {
+			tuiApp.AddSecurityEvent("Secure Boot has been enabled since the last boot")
+		}
+	}
 
 	// Run the daemon.
 	err = run(ctx, s)
@@ -260,6 +291,27 @@ func run(ctx context.Context, s *state.State) error {
 		os.Exit(1) //nolint:revive
 	}
 
+	// Warn the user if the state was written by a newer version, such as when booting the recovery image.
+	if s.NewerStateVersion {
+		slog.ErrorContext(ctx, "Existing state was written by a newer version; no changes will be written to disk", "version", s.StateVersion)
+	}
+
+	// Warn the user if the existing state breaks an invariant.
+	if s.InvalidStateError != nil {
+		slog.ErrorContext(ctx, "Existing state is invalid; no changes will be written to disk", "err", s.InvalidStateError)
+	}
+
+	// Let the user know if the existing state couldn't be parsed and was replaced by a new one.
+	if s.QuarantinedPath != "" {
+		t, tuiErr := tui.GetTUI(nil)
+		if tuiErr == nil {
+			modal := t.AddModal(s.OS.Name, "state-quarantine")
+			modal.Update("[red]The existing state couldn't be parsed and was replaced by a new one.[white]\nThe previous state was moved to " + s.QuarantinedPath + ".")
+		}
+
+		slog.ErrorContext(ctx, "Existing state couldn't be parsed and was replaced by a new one", "path", s.QuarantinedPath)
+	}
+
 	// Warn the user if we failed to read any configuration fields from state.
 	if len(s.UnrecognizedFields) > 0 {
 		slog.ErrorContext(ctx, "Failed to fully parse existing state; no changes will be written to disk")
@@ -349,6 +401,12 @@ func run(ctx context.Context, s *state.State) error {
 	s.OS.SuccessfulBoot = true
 	s.OS.SystemIsReady = true
 
+	// Past early boot, stop clearing the console so any messages remain readable.
+	t, err := tui.GetTUI(nil)
+	if err == nil {
+		t.SetConsoleClear(false)
+	}
+
 	err = providers.Notify(ctx, s, ocapi.ServerSelfUpdateCauseSystemIsReady)
 	if err != nil {
 		return err
@@ -839,7 +897,9 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 
 	// Run periodic update checks if we have a working provider.
 	if p != nil {
-		go update.Checker(ctx, s, p, false, false)
+		tui.SafeGo("update checker", func() {
+			update.Checker(ctx, s, p, false, false)
+		})
 	}
 
 	// Handle registration.
@@ -928,11 +988,11 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 
 	if delayInitialUpdateCheck {
 		// Queue a delayed initial start update check 30 seconds after the system has started up.
-		go func() {
+		tui.SafeGo("initial update check", func() {
 			time.Sleep(30 * time.Second)
 
 			update.Checker(ctx, s, p, true, false)
-		}()
+		})
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"slices"
+	"time"
 
 	"github.com/lxc/incus-os/incus-osd/api"
 	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
@@ -101,11 +102,21 @@ func (*common) IsPrimary() bool {
 	return false
 }
 
+// InstalledAt returns when the application was first installed, if known.
+func (a *common) InstalledAt() *time.Time {
+	return a.appState.InstalledAt
+}
+
 // IsRunning reports if the application is currently running.
 func (*common) IsRunning(_ context.Context) bool {
 	return false
 }
 
+// ListeningPorts returns the network ports the application listens on by default.
+func (*common) ListeningPorts() []int {
+	return nil
+}
+
 // NeedsLateUpdateCheck reports if the application depends on a delayed provider update check.
 func (*common) NeedsLateUpdateCheck() bool {
 	return false
@@ -136,6 +147,12 @@ func (*common) StartupWeight() int {
 
 // SetVersions sets the actual and available versions for the application.
 func (a *common) SetVersions(version string, availableVersions []string) {
+	// Record when the application is first installed.
+	if a.appState.Version == "" && version != "" {
+		now := time.Now()
+		a.appState.InstalledAt = &now
+	}
+
 	a.appState.Version = version
 	a.appState.AvailableVersions = availableVersions
 }
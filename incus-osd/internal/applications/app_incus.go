@@ -263,6 +263,11 @@ func (*incus) IsRunning(ctx context.Context) bool {
 	return systemd.IsActive(ctx, "incus.service")
 }
 
+// ListeningPorts returns the network ports the application listens on by default.
+func (*incus) ListeningPorts() []int {
+	return []int{8443}
+}
+
 func (a *incus) Name() string {
 	return a.incusVersion
 }
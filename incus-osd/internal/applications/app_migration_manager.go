@@ -273,6 +273,11 @@ func (*migrationManager) IsRunning(ctx context.Context) bool {
 	return systemd.IsActive(ctx, "migration-manager.service")
 }
 
+// ListeningPorts returns the network ports the application listens on by default.
+func (*migrationManager) ListeningPorts() []int {
+	return []int{8443}
+}
+
 func (*migrationManager) Name() string {
 	return "migration-manager"
 }
@@ -154,6 +154,11 @@ func (*openfga) IsRunning(ctx context.Context) bool {
 	return systemd.IsActive(ctx, "openfga.service")
 }
 
+// ListeningPorts returns the network ports the application listens on by default.
+func (*openfga) ListeningPorts() []int {
+	return []int{8444}
+}
+
 func (*openfga) Name() string {
 	return "openfga"
 }
@@ -313,6 +313,11 @@ func (*operationsCenter) IsRunning(ctx context.Context) bool {
 	return systemd.IsActive(ctx, "operations-center.service")
 }
 
+// ListeningPorts returns the network ports the application listens on by default.
+func (*operationsCenter) ListeningPorts() []int {
+	return []int{8443}
+}
+
 func (*operationsCenter) Name() string {
 	return "operations-center"
 }
@@ -90,10 +90,31 @@ func UninstallApplication(ctx context.Context, s *state.State, name string) erro
 		return err
 	}
 
+	s.AddEvent(state.EventTypeApplication, "Removed application "+name)
+
 	// Save the state to disk.
 	return s.Save()
 }
 
+// GetStatus returns a short description of the application's current status.
+func GetStatus(ctx context.Context, app Application) string {
+	switch {
+	case !app.IsInitialized():
+		return "not initialized"
+	case app.IsRunning(ctx):
+		return "running"
+	default:
+		return "stopped"
+	}
+}
+
+// SetRuntimeState fills in the fields of the application state which aren't persisted to disk.
+func SetRuntimeState(ctx context.Context, app Application, appState *api.ApplicationState) {
+	appState.IsPrimary = app.IsPrimary()
+	appState.Status = GetStatus(ctx, app)
+	appState.ListeningPorts = app.ListeningPorts()
+}
+
 // StartInitialize starts the specified application, and if needed performs initialization actions.
 func StartInitialize(ctx context.Context, s *state.State, appName string) error {
 	// Get the application.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"io"
+	"time"
 
 	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
 )
@@ -24,10 +25,12 @@ type Application interface { //nolint:interfacebloat
 	GetDependencies() []string
 	GetServerCertificate() (*tls.Certificate, error)
 	Initialize(ctx context.Context) error
+	InstalledAt() *time.Time
 	IsInitialized() bool
 	IsInstalled() bool
 	IsPrimary() bool
 	IsRunning(ctx context.Context) bool
+	ListeningPorts() []int
 	Name() string
 	NeedsLateUpdateCheck() bool
 	Restart(ctx context.Context) error
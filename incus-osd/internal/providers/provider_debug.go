@@ -194,7 +194,7 @@ func (p *debug) checkRelease(_ context.Context) error {
 	return nil
 }
 
-func (p *debug) copyAsset(_ context.Context, name string, targetPath string, progressFunc func(float64)) error {
+func (p *debug) copyAsset(_ context.Context, name string, targetPath string, progressFunc func(int64, int64)) error {
 	// Remove the target file, if it exists. If we don't, truncating the existing file causes spurious
 	// kernel log messages about verity device-mapper corrupted data blocks for sysext images.
 	err := os.Remove(filepath.Join(targetPath, name))
@@ -217,7 +217,7 @@ func (p *debug) copyAsset(_ context.Context, name string, targetPath string, pro
 		return err
 	}
 
-	srcSize := float64(s.Size())
+	srcSize := s.Size()
 
 	// Open the destination.
 	// #nosec G304
@@ -243,7 +243,7 @@ func (p *debug) copyAsset(_ context.Context, name string, targetPath string, pro
 
 		// Update progress every 24MiB.
 		if progressFunc != nil && count%6 == 0 {
-			progressFunc(float64(count*4*1024*1024) / srcSize)
+			progressFunc(count*4*1024*1024, srcSize)
 		}
 
 		count++
@@ -273,7 +273,7 @@ func (a *debugApplication) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(a.version, otherVersion)
 }
 
-func (a *debugApplication) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (a *debugApplication) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
@@ -320,7 +320,7 @@ func (o *debugOSUpdate) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(o.version, otherVersion)
 }
 
-func (o *debugOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (o *debugOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Clear the path.
 	err := os.RemoveAll(targetPath)
 	if err != nil && !os.IsNotExist(err) {
@@ -360,7 +360,7 @@ func (o *debugOSUpdate) Download(ctx context.Context, targetPath string, progres
 	return nil
 }
 
-func (*debugOSUpdate) DownloadImage(_ context.Context, _ string, _ string, _ func(float64)) (string, error) {
+func (*debugOSUpdate) DownloadImage(_ context.Context, _ string, _ string, _ func(int64, int64)) (string, error) {
 	// No reason to support fetching a full install image from the debug (development) provider.
 	return "", errors.New("downloading full image not supported by debug provider")
 }
@@ -385,7 +385,7 @@ func (o *debugSecureBootCertUpdate) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(o.version, otherVersion)
 }
 
-func (o *debugSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(float64)) error {
+func (o *debugSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(int64, int64)) error {
 	for _, asset := range o.assets {
 		// Only select Secure Boot keys for the expected version.
 		if filepath.Base(asset) != o.GetFilename() {
@@ -532,7 +532,7 @@ func (a *imagesApplication) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(a.latestUpdate.Version, otherVersion)
 }
 
-func (a *imagesApplication) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (a *imagesApplication) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
@@ -579,7 +579,7 @@ func (o *imagesOSUpdate) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(o.latestUpdate.Version, otherVersion)
 }
 
-func (o *imagesOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (o *imagesOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Clear the target path.
 	err := os.RemoveAll(targetPath)
 	if err != nil && !os.IsNotExist(err) {
@@ -611,7 +611,7 @@ func (o *imagesOSUpdate) Download(ctx context.Context, targetPath string, progre
 	return nil
 }
 
-func (o *imagesOSUpdate) DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(float64)) (string, error) {
+func (o *imagesOSUpdate) DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(int64, int64)) (string, error) {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
@@ -655,7 +655,7 @@ func (o *imagesSecureBootCertUpdate) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(o.latestUpdate.Version, otherVersion)
 }
 
-func (o *imagesSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(float64)) error {
+func (o *imagesSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(int64, int64)) error {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
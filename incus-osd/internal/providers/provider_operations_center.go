@@ -620,7 +620,7 @@ func (a *operationsCenterApplication) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(a.latestUpdate.Version, otherVersion)
 }
 
-func (a *operationsCenterApplication) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (a *operationsCenterApplication) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
@@ -666,7 +666,7 @@ func (o *operationsCenterOSUpdate) IsNewerThan(otherVersion string) bool {
 	return DatetimeComparison(o.latestUpdate.Version, otherVersion)
 }
 
-func (o *operationsCenterOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(float64)) error {
+func (o *operationsCenterOSUpdate) Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error {
 	// Clear the target path.
 	err := os.RemoveAll(targetPath)
 	if err != nil && !os.IsNotExist(err) {
@@ -697,7 +697,7 @@ func (o *operationsCenterOSUpdate) Download(ctx context.Context, targetPath stri
 	return nil
 }
 
-func (o *operationsCenterOSUpdate) DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(float64)) (string, error) {
+func (o *operationsCenterOSUpdate) DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(int64, int64)) (string, error) {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
@@ -740,7 +740,7 @@ func (o *operationsCenterSecureBootCertUpdate) IsNewerThan(otherVersion string)
 	return DatetimeComparison(o.latestUpdate.Version, otherVersion)
 }
 
-func (o *operationsCenterSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(float64)) error {
+func (o *operationsCenterSecureBootCertUpdate) Download(ctx context.Context, targetPath string, _ func(int64, int64)) error {
 	// Create the target path.
 	err := os.MkdirAll(targetPath, 0o700)
 	if err != nil {
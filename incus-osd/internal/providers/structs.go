@@ -12,7 +12,7 @@ type CommonUpdate interface {
 	Version() string
 	IsNewerThan(otherVersion string) bool
 
-	Download(ctx context.Context, targetPath string, progressFunc func(float64)) error
+	Download(ctx context.Context, targetPath string, progressFunc func(int64, int64)) error
 }
 
 // ApplicationUpdate represents an application to be installed on top of IncusOS.
@@ -26,7 +26,7 @@ type ApplicationUpdate interface {
 type OSUpdate interface {
 	CommonUpdate
 
-	DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(float64)) (string, error)
+	DownloadImage(ctx context.Context, imageType string, targetPath string, progressFunc func(int64, int64)) (string, error)
 }
 
 // SecureBootCertUpdate represents a Secure Boot UEFI certificate update (typically a db or dbx addition).
@@ -13,7 +13,7 @@ import (
 	"time"
 )
 
-func downloadAsset(ctx context.Context, osName string, osVersion string, client *http.Client, assetURL string, expectedSHA256 string, target string, progressFunc func(float64)) error {
+func downloadAsset(ctx context.Context, osName string, osVersion string, client *http.Client, assetURL string, expectedSHA256 string, target string, progressFunc func(int64, int64)) error {
 	// Remove the target file, if it exists. If we don't, truncating the existing file causes spurious
 	// kernel log messages about verity device-mapper corrupted data blocks for sysext images.
 	err := os.Remove(target)
@@ -45,8 +45,9 @@ func downloadAsset(ctx context.Context, osName string, osVersion string, client
 	// Setup a sha256 hasher.
 	h := sha256.New()
 
-	// Setup the main reader.
-	tr := io.TeeReader(resp.Body, h)
+	// Setup the main reader, keeping track of how much was downloaded.
+	downloaded := &countingWriter{}
+	tr := io.TeeReader(resp.Body, io.MultiWriter(h, downloaded))
 
 	// Setup a gzip reader to decompress during streaming.
 	body, err := gzip.NewReader(tr)
@@ -80,7 +81,7 @@ func downloadAsset(ctx context.Context, osName string, osVersion string, client
 
 		// Update progress every 24MiB.
 		if progressFunc != nil && count%6 == 0 {
-			progressFunc(float64(count*4*1024*1024) / float64(resp.ContentLength))
+			progressFunc(downloaded.count, resp.ContentLength)
 		}
 
 		count++
@@ -111,3 +112,14 @@ func tryRequest(client *http.Client, req *http.Request) (*http.Response, error)
 
 	return nil, fmt.Errorf("http request timed out after five seconds: %w", err)
 }
+
+// countingWriter counts the number of bytes written to it.
+type countingWriter struct {
+	count int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += int64(len(p))
+
+	return len(p), nil
+}
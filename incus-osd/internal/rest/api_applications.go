@@ -342,18 +342,20 @@ func (s *Server) apiApplicationsEndpoint(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		ctx := r.Context()
+
 		// This is a bit ugly, but is the cleanest way to generically report
-		// if an application is primary or not via the REST API without
-		// resorting to reflection hacks.
+		// runtime information such as if an application is primary or not via
+		// the REST API without resorting to reflection hacks.
 		switch r := resp.(type) {
 		case api.Application:
-			r.State.IsPrimary = app.IsPrimary()
+			applications.SetRuntimeState(ctx, app, &r.State)
 			resp = r
 		case api.ApplicationIncus:
-			r.State.IsPrimary = app.IsPrimary()
+			applications.SetRuntimeState(ctx, app, &r.State.ApplicationState)
 			resp = r
 		case api.ApplicationOpenFGA:
-			r.State.IsPrimary = app.IsPrimary()
+			applications.SetRuntimeState(ctx, app, &r.State.ApplicationState)
 			resp = r
 		default:
 			_ = response.InternalError(fmt.Errorf("unrecognized application type %T", resp)).Render(w)
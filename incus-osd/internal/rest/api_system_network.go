@@ -9,11 +9,9 @@ import (
 	"time"
 
 	"github.com/lxc/incus-os/incus-osd/api"
-	"github.com/lxc/incus-os/incus-osd/internal/nftables"
 	"github.com/lxc/incus-os/incus-osd/internal/providers"
 	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
 	"github.com/lxc/incus-os/incus-osd/internal/seed"
-	"github.com/lxc/incus-os/incus-osd/internal/state"
 	"github.com/lxc/incus-os/incus-osd/internal/systemd"
 )
 
@@ -176,7 +174,7 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 					if err != nil {
 						slog.WarnContext(ctx, "Invalid network configuration detected, rolling back to prior known-good state")
 
-						err = applyNetworkConfiguration(ctx, s.state, s.state.PriorNetworkConfig, 30*time.Second)
+						err = systemd.RollbackNetworkConfiguration(ctx, s.state, 30*time.Second, providers.Notify)
 						if err != nil {
 							slog.ErrorContext(ctx, "Failed to roll back network configuration: "+err.Error())
 						}
@@ -186,7 +184,7 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 					// so we need to roll the changes back.
 					slog.WarnContext(ctx, "Timeout expired, rolling back network configuration to prior known-good state")
 
-					err = applyNetworkConfiguration(ctx, s.state, s.state.PriorNetworkConfig, 30*time.Second)
+					err = systemd.RollbackNetworkConfiguration(ctx, s.state, 30*time.Second, providers.Notify)
 					if err != nil {
 						slog.ErrorContext(ctx, "Failed to roll back network configuration: "+err.Error())
 					}
@@ -211,7 +209,7 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 
 		slog.InfoContext(r.Context(), "Applying new network configuration")
 
-		err = applyNetworkConfiguration(r.Context(), s.state, newConfig.Config, applyTimeout)
+		err = systemd.ApplyAndSaveNetworkConfiguration(r.Context(), s.state, newConfig.Config, applyTimeout, providers.Notify)
 		if err != nil {
 			if s.state.NetworkConfigurationPending {
 				// Trigger an immediate rollback of the bad configuration.
@@ -231,20 +229,6 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func applyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration) error {
-	err := nftables.ApplyHwaddrFilters(ctx, networkCfg)
-	if err != nil {
-		return err
-	}
-
-	err = systemd.ApplyNetworkConfiguration(ctx, s, networkCfg, timeout, false, providers.Notify, false)
-	if err != nil {
-		return err
-	}
-
-	return s.Save()
-}
-
 // swagger:operation POST /1.0/system/network/:confirm system system_post_network_confirm
 //
 //	Confirm a new network configuration
@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus-os/incus-osd/internal/secureboot"
 	"github.com/lxc/incus-os/incus-osd/internal/storage"
 	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+	"github.com/lxc/incus-os/incus-osd/internal/tui"
 	"github.com/lxc/incus-os/incus-osd/internal/util"
 	"github.com/lxc/incus-os/incus-osd/internal/zfs"
 )
@@ -228,6 +229,11 @@ func (s *Server) apiSystemSecurity(w http.ResponseWriter, r *http.Request) {
 
 		slog.InfoContext(r.Context(), "Custom CA certificates updated, but may not fully take effect until the system is rebooted")
 
+		t, err := tui.GetTUI(nil)
+		if err == nil {
+			t.AddSecurityEvent("Security configuration (encryption recovery keys and custom CA certificates) was updated via the API")
+		}
+
 		_ = response.EmptySyncResponse.Render(w)
 	default:
 		// If none of the supported methods, return NotImplemented.
@@ -42,7 +42,7 @@ import (
 //	        metadata:
 //	          type: json
 //	          description: State and configuration for the system update
-//	          example: {"config":{"auto_reboot":false,"channel":"stable","check_frequency":"6h"},"state":{"last_check":"2025-11-04T16:21:34.929524792Z","status":"Update check completed","needs_reboot":false}}
+//	          example: {"config":{"auto_reboot":false,"channel":"stable","check_frequency":"6h"},"state":{"last_check":"2025-11-04T16:21:34.929524792Z","status":"Update check completed","needs_reboot":false,"image_signature":"verified"}}
 
 // swagger:operation PUT /1.0/system/update system system_put_update
 //
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/lxc/incus-os/incus-osd/internal/state"
+	"github.com/lxc/incus-os/incus-osd/internal/tui"
 )
 
 // Server holds the internal state of the REST API server.
@@ -136,6 +137,11 @@ func (s *Server) Serve() error {
 					}
 
 					if !foundTrustedClient {
+						t, err := tui.GetTUI(nil)
+						if err == nil {
+							t.AddSecurityEvent("Rejected API request from " + r.RemoteAddr + " using an untrusted client certificate")
+						}
+
 						http.Error(w, "Forbidden", http.StatusForbidden)
 
 						return
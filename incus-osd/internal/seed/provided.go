@@ -0,0 +1,95 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lxc/incus/v7/shared/subprocess"
+)
+
+var (
+	providedMutex sync.Mutex
+	provided      = map[string][]byte{}
+)
+
+// Provide records the content of a seed file, such as "network" or "applications", for the rest of
+// this boot. It takes precedence over any seed data on disk, letting configuration gathered through
+// other means, such as the console setup wizard, be handled exactly like a seed file.
+func Provide(filename string, config any) error {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	providedMutex.Lock()
+	defer providedMutex.Unlock()
+
+	provided[filename] = body
+
+	return nil
+}
+
+// Persist writes a seed file recorded through Provide to the local "seed-data" partition, so it
+// still applies after a reboot, as if it had been part of the install media.
+func Persist(ctx context.Context, filename string) error {
+	providedMutex.Lock()
+	body, ok := provided[filename]
+	providedMutex.Unlock()
+
+	if !ok {
+		return errors.New("no seed data provided for " + filename)
+	}
+
+	partition := "/dev/disk/by-partlabel/seed-data"
+
+	tmpDir, err := os.MkdirTemp("", "incus-os-seed")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = os.WriteFile(filepath.Join(tmpDir, filename+".json"), body, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = removeSeed(ctx, partition, filename)
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "tar", "-f", partition, "-C", tmpDir, "--append", "--add-file", filename+".json")
+
+	return err
+}
+
+// Exists returns true if the named seed file is present, either on disk or through Provide.
+func Exists(filename string) bool {
+	var target any
+
+	err := parseFileContents(getSeedPath(), filename, &target)
+
+	// A seed file which fails to parse is still present.
+	return err == nil || !IsMissing(err)
+}
+
+// parseProvidedContents decodes a seed file recorded through Provide, returning false if there's none.
+func parseProvidedContents(filename string, target any) (bool, error) {
+	providedMutex.Lock()
+	body, ok := provided[filename]
+	providedMutex.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	return true, decoder.Decode(target)
+}
@@ -81,15 +81,13 @@ func CleanupPostInstall(ctx context.Context, targetSeedPartition string) error {
 			}
 
 			// Remove any existing seed from the target seed partition.
-			for _, filename := range []string{seedName + ".json", seedName + ".yaml", seedName + ".yml"} {
-				_, err := subprocess.RunCommandContext(ctx, "tar", "-f", targetSeedPartition, "--delete", filename)
-				if err != nil && !strings.Contains(err.Error(), fmt.Sprintf("tar: %s: Not found in archive", filename)) {
-					return err
-				}
+			err := removeSeed(ctx, targetSeedPartition, seedName)
+			if err != nil {
+				return err
 			}
 
 			// Append the external seed to the target seed partition.
-			_, err := subprocess.RunCommandContext(ctx, "tar", "-f", targetSeedPartition, "-C", mountDir, "--append", "--add-file", file.Name())
+			_, err = subprocess.RunCommandContext(ctx, "tar", "-f", targetSeedPartition, "-C", mountDir, "--append", "--add-file", file.Name())
 			if err != nil {
 				return err
 			}
@@ -99,6 +97,18 @@ func CleanupPostInstall(ctx context.Context, targetSeedPartition string) error {
 	return nil
 }
 
+// removeSeed removes any variant of the named seed file from the provided seed partition.
+func removeSeed(ctx context.Context, partition string, seedName string) error {
+	for _, filename := range []string{seedName + ".json", seedName + ".yaml", seedName + ".yml"} {
+		_, err := subprocess.RunCommandContext(ctx, "tar", "-f", partition, "--delete", filename)
+		if err != nil && !strings.Contains(err.Error(), fmt.Sprintf("tar: %s: Not found in archive", filename)) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // getSeedPath defines the path to the expected seed configuration. It will first search for any
 // disk with a "SEED_DATA" label, which would be externally provided by the user. If not found,
 // defaults to the "seed-data" partition that exists on install media.
@@ -118,8 +128,14 @@ func getSeedPath() string {
 
 // parseFileContents searches for a given file in the seed configuration and returns its contents as a byte array if found.
 func parseFileContents(partition string, filename string, target any) error {
+	// Seed data provided at runtime takes precedence.
+	found, err := parseProvidedContents(filename, target)
+	if found {
+		return err
+	}
+
 	// First, try to get seed data by mounting a user-provided seed.
-	err := parseFileContentsFromUserPartition(partition, filename, target)
+	err = parseFileContentsFromUserPartition(partition, filename, target)
 	if err == nil {
 		return nil
 	}
@@ -52,3 +52,21 @@ func TestBadYAMLFields(t *testing.T) {
 
 	require.Error(t, err, "line 3: field disable_everything not found in type seed.InstallSecurity")
 }
+
+func TestProvidedSeed(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, Exists("provided-test"))
+
+	err := Provide("provided-test", apiseed.Applications{Applications: []apiseed.Application{{Name: "incus"}}})
+	require.NoError(t, err)
+	require.True(t, Exists("provided-test"))
+
+	var config apiseed.Applications
+
+	err = parseFileContents("testdata.tar", "provided-test", &config)
+
+	require.NoError(t, err)
+	require.Len(t, config.Applications, 1)
+	require.Equal(t, "incus", config.Applications[0].Name)
+}
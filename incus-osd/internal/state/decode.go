@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var errUnrecognizedConfigField = errors.New("unrecognized configuration field")
@@ -35,6 +36,13 @@ func Decode(b []byte, upgradeFuncs UpgradeFuncs, s *State) error {
 			upgradeFuncs = upgrades
 		}
 
+		// A state written by a newer version may use fields we don't know about, typically when
+		// booting into the recovery OS image after an upgrade. Load what we can, but record it so
+		// that the state is never written back, as any newer fields would be lost.
+		if version > len(upgradeFuncs) {
+			s.NewerStateVersion = true
+		}
+
 		// Apply any needed upgrade functions to the input.
 		for i := version; i < len(upgradeFuncs); i++ {
 			if upgradeFuncs[i] != nil {
@@ -57,6 +65,8 @@ func Decode(b []byte, upgradeFuncs UpgradeFuncs, s *State) error {
 	}
 
 	// Parse each line.
+	seen := map[string]bool{}
+
 	for _, line := range lines {
 		if line == "" || whitespaceRegex.MatchString(line) || strings.HasPrefix(line, "#") {
 			continue
@@ -67,6 +77,13 @@ func Decode(b []byte, upgradeFuncs UpgradeFuncs, s *State) error {
 			return fmt.Errorf("malformed line '%s'", line)
 		}
 
+		// A repeated key would silently override the earlier value, so record it for validation.
+		if seen[parts[0]] {
+			s.duplicateFields = append(s.duplicateFields, parts[0])
+		}
+
+		seen[parts[0]] = true
+
 		err := decodeHelper(reflect.ValueOf(s), strings.Split(parts[0], "."), parts[1])
 		if err != nil {
 			if !errors.Is(err, errUnrecognizedConfigField) {
@@ -182,6 +199,22 @@ func decodeHelper(v reflect.Value, keys []string, value string) error {
 
 // setValue is a helper function to convert and set a string representation of a value.
 func setValue(v reflect.Value, value string) error {
+	// Pointers have already been allocated while walking the state struct.
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Type() == reflect.TypeFor[time.Time]() {
+		tVal, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(tVal))
+
+		return nil
+	}
+
 	// Set the value.
 	switch v.Kind() { //nolint:exhaustive
 	case reflect.Bool:
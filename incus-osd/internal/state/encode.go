@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Encode encodes the state and returns an array of bytes.
@@ -38,6 +39,13 @@ func encodeHelper(b *bytes.Buffer, keyPrefix []string, v reflect.Value) error {
 		return nil
 	}
 
+	// Timestamps only have unexported fields, so serialize them as text.
+	if v.Type() == reflect.TypeFor[time.Time]() {
+		_, err := fmt.Fprintf(b, "%s: %s\n", strings.Join(keyPrefix, "."), v.Interface().(time.Time).Format(time.RFC3339Nano)) //nolint:forcetypeassert
+
+		return err
+	}
+
 	switch v.Kind() { //nolint:exhaustive
 	case reflect.Bool:
 		_, err := fmt.Fprintf(b, "%s: %v\n", strings.Join(keyPrefix, "."), v.Bool())
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"time"
+)
+
+// Maximum number of events retained in the state.
+const maxEvents = 100
+
+// Types of events recorded in the state.
+const (
+	EventTypeApplication = "application"
+	EventTypeNetwork     = "network"
+	EventTypeUpdate      = "update"
+)
+
+// Event represents a single action performed by the daemon, kept for later review.
+type Event struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// AddEvent records a new event, dropping the oldest ones once the limit is reached.
+// The event is only persisted on the next save of the state.
+func (s *State) AddEvent(eventType string, message string) {
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+
+	s.Events = append(s.Events, Event{
+		Type:    eventType,
+		Time:    time.Now(),
+		Message: message,
+	})
+
+	if len(s.Events) > maxEvents {
+		s.Events = s.Events[len(s.Events)-maxEvents:]
+	}
+}
+
+// GetEvents returns a copy of the recorded events, oldest first.
+func (s *State) GetEvents() []Event {
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+
+	events := make([]Event, len(s.Events))
+	copy(events, s.Events)
+
+	return events
+}
@@ -0,0 +1,85 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Export returns a portable snapshot of the persistent state, including its version.
+func (s *State) Export(_ context.Context) ([]byte, error) {
+	return Encode(s)
+}
+
+// Import validates the provided snapshot, upgrading it if it comes from an older version, then
+// replaces the persistent state with it and saves it to disk. Runtime-only fields are preserved.
+func (s *State) Import(_ context.Context, body []byte) error {
+	// Importing would otherwise appear to succeed, while Save silently refuses to write it.
+	if s.isReadOnly() {
+		return errors.New("the existing state wasn't fully loaded and can't be replaced")
+	}
+
+	if !bytes.HasPrefix(body, []byte("#Version: ")) {
+		return errors.New("state snapshot is missing its version")
+	}
+
+	var imported State
+
+	err := Decode(body, nil, &imported)
+	if err != nil {
+		return fmt.Errorf("invalid state snapshot: %w", err)
+	}
+
+	if imported.NewerStateVersion {
+		return fmt.Errorf("state snapshot version %d is newer than the highest supported version %d", imported.StateVersion, currentStateVersion)
+	}
+
+	if len(imported.UnrecognizedFields) > 0 {
+		return fmt.Errorf("state snapshot contains unrecognized fields: %s", strings.Join(imported.UnrecognizedFields, ", "))
+	}
+
+	initializeMaps(reflect.ValueOf(&imported).Elem())
+
+	err = imported.validate()
+	if err != nil {
+		return fmt.Errorf("invalid state snapshot: %w", err)
+	}
+
+	// Swap in the imported state while holding the lock, so no other save can interleave.
+	err = s.save(func() {
+		copyPersistentFields(reflect.ValueOf(s).Elem(), reflect.ValueOf(&imported).Elem())
+		s.StateVersion = imported.StateVersion
+	})
+	if err != nil {
+		return err
+	}
+
+	s.runSaveCallbacks()
+
+	return nil
+}
+
+// copyPersistentFields recursively copies all fields which are part of the on-disk state from
+// src to dst, skipping the same fields as the encoder.
+func copyPersistentFields(dst reflect.Value, src reflect.Value) {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+
+		if !field.IsExported() || field.Tag.Get("json") == "-" || field.Tag.Get("incusos") == "-" {
+			continue
+		}
+
+		// Timestamps are copied as a whole, as their fields are unexported.
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeFor[time.Time]() {
+			copyPersistentFields(dst.Field(i), src.Field(i))
+
+			continue
+		}
+
+		dst.Field(i).Set(src.Field(i))
+	}
+}
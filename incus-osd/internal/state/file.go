@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus-os/incus-osd/internal/scheduling"
 )
 
-var currentStateVersion = 8
+var currentStateVersion = 11
 
 // LoadOrCreate parses the on-disk state file and returns a State struct.
 // If no file exists, a new empty one is created.
@@ -28,20 +34,43 @@ func LoadOrCreate(path string) (*State, error) {
 		NetworkConfigurationChannel: make(chan error, 1),
 	}
 
-	body, err := os.ReadFile(s.path)
+	body, err := readFile(s.path)
 	if err == nil {
 		err = Decode(body, nil, &s)
+		if err == nil {
+			// A state which parses but breaks an invariant still holds the system's configuration,
+			// so keep it rather than starting over, but don't allow writing it back.
+			initializeMaps(reflect.ValueOf(&s).Elem())
+			s.InvalidStateError = s.validate()
+
+			return &s, nil
+		}
+
+		// Rather than failing to boot, move the unparseable state aside and start over.
+		s.QuarantinedPath = s.path + ".corrupt-" + time.Now().UTC().Format("20060102-150405")
+
+		slog.Error("Existing state can't be parsed, moving it aside and starting with a new state", "err", err, "path", s.QuarantinedPath)
 
-		return &s, err
+		err = os.Rename(s.path, s.QuarantinedPath)
+		if err != nil {
+			return nil, err
+		}
+
+		copyPersistentFields(reflect.ValueOf(&s).Elem(), reflect.ValueOf(&State{}).Elem())
+		s.StateVersion = currentStateVersion
+		s.NewerStateVersion = false
+		s.UnrecognizedFields = nil
 	}
 
-	if os.IsNotExist(err) {
+	if err == nil || os.IsNotExist(err) {
 		// Initialize with default values.
 		err = s.initialize()
 		if err != nil {
 			return nil, err
 		}
 
+		initializeMaps(reflect.ValueOf(&s).Elem())
+
 		// State file doesn't exist, create it and return it.
 		err = s.Save()
 		if err != nil {
@@ -54,15 +83,76 @@ func LoadOrCreate(path string) (*State, error) {
 	return nil, err
 }
 
+// OnSave registers a callback to be run after each successful save of the state.
+func (s *State) OnSave(f func(*State)) {
+	s.saveCallbacksMutex.Lock()
+	defer s.saveCallbacksMutex.Unlock()
+
+	s.saveCallbacks = append(s.saveCallbacks, f)
+}
+
 // Save writes out the current state struct into its on-disk storage.
 func (s *State) Save() error {
 	// If we failed to fully load the existing state, refuse to save any changes to prevent accidental data loss.
-	if len(s.UnrecognizedFields) > 0 {
+	if s.isReadOnly() {
 		slog.Error("Refusing to save state because we previously failed to properly load the existing state")
 
 		return nil
 	}
 
+	err := s.save(nil)
+	if err != nil {
+		return err
+	}
+
+	s.runSaveCallbacks()
+
+	return nil
+}
+
+// isReadOnly returns true if the existing state wasn't fully loaded, and so must not be written back.
+func (s *State) isReadOnly() bool {
+	return len(s.UnrecognizedFields) > 0 || s.NewerStateVersion || s.InvalidStateError != nil
+}
+
+// runSaveCallbacks runs all registered save callbacks.
+func (s *State) runSaveCallbacks() {
+	s.saveCallbacksMutex.Lock()
+	callbacks := slices.Clone(s.saveCallbacks)
+	s.saveCallbacksMutex.Unlock()
+
+	for _, f := range callbacks {
+		runSaveCallback(f, s)
+	}
+}
+
+// runSaveCallback runs a single save callback, ensuring a panic doesn't take down the daemon.
+func runSaveCallback(f func(*State), s *State) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			slog.Error("State save callback panicked", "err", r)
+		}
+	}()
+
+	f(s)
+}
+
+// save encodes the state and atomically writes it to disk. If provided, update is run first while
+// holding the lock, allowing the state to be replaced without racing other writers.
+func (s *State) save(update func()) error {
+	// Serialize writers and prevent readers from seeing the state mid-update.
+	unlock, err := lockFile(s.path, unix.LOCK_EX)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if update != nil {
+		update()
+	}
+
 	body, err := Encode(s)
 	if err != nil {
 		return err
@@ -79,7 +169,63 @@ func (s *State) Save() error {
 	}
 
 	// Overwrite the prior state file with the updated version.
-	return os.Rename(s.path+".tmp", s.path)
+	err = os.Rename(s.path+".tmp", s.path)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the rename itself has been persisted to disk.
+	return syncDir(filepath.Dir(s.path))
+}
+
+// readFile reads the state file while holding a shared lock.
+func readFile(path string) ([]byte, error) {
+	// Only attempt locking if the state file exists, so its absence is reported as usual.
+	_, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockFile(path, unix.LOCK_SH)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return os.ReadFile(path)
+}
+
+// lockFile takes an advisory lock of the requested type on the state file, returning a function
+// to release it. A separate lock file is used, as the state file itself is replaced on save.
+func lockFile(path string, how int) (func(), error) {
+	fd, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unix.Flock(int(fd.Fd()), how) // #nosec G115
+	if err != nil {
+		_ = fd.Close()
+
+		return nil, err
+	}
+
+	return func() {
+		_ = unix.Flock(int(fd.Fd()), unix.LOCK_UN) // #nosec G115
+		_ = fd.Close()
+	}, nil
+}
+
+func syncDir(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	return fd.Sync()
 }
 
 func writeFile(filename string, body []byte) error {
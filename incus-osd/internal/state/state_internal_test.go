@@ -1,7 +1,9 @@
 package state
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,3 +14,20 @@ func TestSchemaVersion(t *testing.T) {
 
 	require.Equal(t, len(upgrades), currentStateVersion)
 }
+
+// Make sure that timestamps are copied as a whole when importing a state.
+func TestCopyPersistentFieldsTime(t *testing.T) {
+	t.Parallel()
+
+	type status struct {
+		Start time.Time
+	}
+
+	now := time.Now()
+	src := status{Start: now}
+
+	var dst status
+
+	copyPersistentFields(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(&src).Elem())
+	require.True(t, now.Equal(dst.Start))
+}
@@ -1,11 +1,16 @@
 package state_test
 
 import (
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/lxc/incus-os/incus-osd/api"
 	"github.com/lxc/incus-os/incus-osd/internal/state"
 )
 
@@ -268,6 +273,102 @@ System.Update.Config.CheckFrequency: 6h0m0s
 System.Storage.Config.ScrubSchedule: 0 4 * * 0
 `
 
+var goldEncodingV9 = `#Version: 9
+Applications.Incus.State.Initialized: true
+Applications.Incus.State.Version: 202506241635
+OS.Name: IncusOS
+OS.RunningRelease: 202506241635
+OS.NextRelease: 202506241635
+System.Network.Config.Time.NTPServers[0]: ntp.example.org
+System.Network.Config.Proxy.Rules[0].Destination: http://*
+System.Network.Config.Proxy.Rules[0].Target: anonymous-proxy_example_org_1234
+System.Network.Config.Proxy.Rules[1].Destination: https://*
+System.Network.Config.Proxy.Rules[1].Target: proxy_example_net_8080
+System.Network.Config.Proxy.Rules[2].Destination: *.example.org|*.example.net
+System.Network.Config.Proxy.Rules[2].Target: direct
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Auth: anonymous
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Host: anonymous-proxy.example.org:1234
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Auth: basic
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Host: proxy.example.net:8080
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Password: pass
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Username: user
+System.Network.Config.Interfaces[0].Addresses[0]: dhcp4
+System.Network.Config.Interfaces[0].Addresses[1]: slaac
+System.Network.Config.Interfaces[0].Hwaddr: 10:66:6a:7c:8c:b0
+System.Network.Config.Interfaces[0].Name: enp5s0
+System.Provider.Config.Name: debug
+System.Provider.Config.Config[multiline_value]: first\nsecond\nthird
+System.Security.Config.EncryptionRecoveryKeys[0]: ebbbibiu-ltgjfuhk-gvutdrvu-hijhvfje-gvlrgrfv-ndekdtdh-ghteuklj-ldedfifb
+System.Security.State.EncryptionRecoveryKeysRetrieved: true
+System.Update.Config.Channel: stable
+System.Update.Config.CheckFrequency: 6h0m0s
+System.Storage.Config.ScrubSchedule: 0 4 * * 0
+`
+
+var goldEncodingV10 = `#Version: 10
+Applications.Incus.State.Initialized: true
+Applications.Incus.State.Version: 202506241635
+OS.Name: IncusOS
+OS.RunningRelease: 202506241635
+OS.NextRelease: 202506241635
+System.Network.Config.Time.NTPServers[0]: ntp.example.org
+System.Network.Config.Proxy.Rules[0].Destination: http://*
+System.Network.Config.Proxy.Rules[0].Target: anonymous-proxy_example_org_1234
+System.Network.Config.Proxy.Rules[1].Destination: https://*
+System.Network.Config.Proxy.Rules[1].Target: proxy_example_net_8080
+System.Network.Config.Proxy.Rules[2].Destination: *.example.org|*.example.net
+System.Network.Config.Proxy.Rules[2].Target: direct
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Auth: anonymous
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Host: anonymous-proxy.example.org:1234
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Auth: basic
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Host: proxy.example.net:8080
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Password: pass
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Username: user
+System.Network.Config.Interfaces[0].Addresses[0]: dhcp4
+System.Network.Config.Interfaces[0].Addresses[1]: slaac
+System.Network.Config.Interfaces[0].Hwaddr: 10:66:6a:7c:8c:b0
+System.Network.Config.Interfaces[0].Name: enp5s0
+System.Provider.Config.Name: debug
+System.Provider.Config.Config[multiline_value]: first\nsecond\nthird
+System.Security.Config.EncryptionRecoveryKeys[0]: ebbbibiu-ltgjfuhk-gvutdrvu-hijhvfje-gvlrgrfv-ndekdtdh-ghteuklj-ldedfifb
+System.Security.State.EncryptionRecoveryKeysRetrieved: true
+System.Update.Config.Channel: stable
+System.Update.Config.CheckFrequency: 6h0m0s
+System.Storage.Config.ScrubSchedule: 0 4 * * 0
+`
+
+var goldEncodingV11 = `#Version: 11
+Applications.Incus.State.Initialized: true
+Applications.Incus.State.Version: 202506241635
+OS.Name: IncusOS
+OS.RunningRelease: 202506241635
+OS.NextRelease: 202506241635
+System.Network.Config.Time.NTPServers[0]: ntp.example.org
+System.Network.Config.Proxy.Rules[0].Destination: http://*
+System.Network.Config.Proxy.Rules[0].Target: anonymous-proxy_example_org_1234
+System.Network.Config.Proxy.Rules[1].Destination: https://*
+System.Network.Config.Proxy.Rules[1].Target: proxy_example_net_8080
+System.Network.Config.Proxy.Rules[2].Destination: *.example.org|*.example.net
+System.Network.Config.Proxy.Rules[2].Target: direct
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Auth: anonymous
+System.Network.Config.Proxy.Servers[anonymous-proxy_example_org_1234].Host: anonymous-proxy.example.org:1234
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Auth: basic
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Host: proxy.example.net:8080
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Password: pass
+System.Network.Config.Proxy.Servers[proxy_example_net_8080].Username: user
+System.Network.Config.Interfaces[0].Addresses[0]: dhcp4
+System.Network.Config.Interfaces[0].Addresses[1]: slaac
+System.Network.Config.Interfaces[0].Hwaddr: 10:66:6a:7c:8c:b0
+System.Network.Config.Interfaces[0].Name: enp5s0
+System.Provider.Config.Name: debug
+System.Provider.Config.Config[multiline_value]: first\nsecond\nthird
+System.Security.Config.EncryptionRecoveryKeys[0]: ebbbibiu-ltgjfuhk-gvutdrvu-hijhvfje-gvlrgrfv-ndekdtdh-ghteuklj-ldedfifb
+System.Security.State.EncryptionRecoveryKeysRetrieved: true
+System.Update.Config.Channel: stable
+System.Update.Config.CheckFrequency: 6h0m0s
+System.Storage.Config.ScrubSchedule: 0 4 * * 0
+`
+
 var unrecognizedFieldConfig = `#Version: 5
 Applications[incus].State.Initialized: true
 Applications[incus].State.Version: 202506241635
@@ -329,12 +430,72 @@ func TestDottedMapKey(t *testing.T) {
 	require.Equal(t, s.System.Provider.Config.Config["dotted.key"], newS.System.Provider.Config.Config["dotted.key"])
 }
 
+// Test encoding and decoding a state that contains timestamps.
+func TestTimestamp(t *testing.T) {
+	t.Parallel()
+
+	s := state.State{
+		StateVersion: 8,
+	}
+
+	installedAt := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	s.Applications.Incus.State.InstalledAt = &installedAt
+
+	contents, err := state.Encode(&s)
+	require.NoError(t, err)
+
+	require.Contains(t, string(contents), "Applications.Incus.State.InstalledAt: 2026-01-02T03:04:05.000000006Z\n")
+
+	// Timestamps in fields known to older decoders must not be written, as they fail to parse them.
+	s.Applications.Incus.State.LastRestored = &installedAt
+	s.System.Update.State.LastCheck = installedAt
+
+	contents, err = state.Encode(&s)
+	require.NoError(t, err)
+	require.NotContains(t, string(contents), "LastRestored")
+	require.NotContains(t, string(contents), "LastCheck")
+
+	var newS state.State
+
+	err = state.Decode(contents, nil, &newS)
+	require.NoError(t, err)
+
+	require.Equal(t, installedAt, *newS.Applications.Incus.State.InstalledAt)
+}
+
+// Test that events are bounded and persisted.
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	s := state.State{
+		StateVersion: 8,
+	}
+
+	for i := range 150 {
+		s.AddEvent(state.EventTypeUpdate, "Event "+strconv.Itoa(i))
+	}
+
+	contents, err := state.Encode(&s)
+	require.NoError(t, err)
+
+	var newS state.State
+
+	err = state.Decode(contents, nil, &newS)
+	require.NoError(t, err)
+
+	events := newS.GetEvents()
+	require.Len(t, events, 100)
+	require.Equal(t, "Event 50", events[0].Message)
+	require.Equal(t, state.EventTypeUpdate, events[99].Type)
+	require.Equal(t, s.Events[99].Time.UnixNano(), events[99].Time.UnixNano())
+}
+
 // Test basic custom decoding/encoding of state.
 func TestCustomEncoding(t *testing.T) {
 	t.Parallel()
 
 	// Test upgrading each known old state version.
-	for _, goldVersion := range []string{goldEncodingV0, goldEncodingV1, goldEncodingV2, goldEncodingV3, goldEncodingV4, goldEncodingV5, goldEncodingV6, goldEncodingV7} {
+	for _, goldVersion := range []string{goldEncodingV0, goldEncodingV1, goldEncodingV2, goldEncodingV3, goldEncodingV4, goldEncodingV5, goldEncodingV6, goldEncodingV7, goldEncodingV8, goldEncodingV9, goldEncodingV10} {
 		var s state.State
 
 		err := state.Decode([]byte(goldVersion), nil, &s)
@@ -343,8 +504,8 @@ func TestCustomEncoding(t *testing.T) {
 		content, err := state.Encode(&s)
 		require.NoError(t, err)
 
-		require.Equal(t, goldEncodingV8, string(content))
-		require.Equal(t, 8, s.StateVersion)
+		require.Equal(t, goldEncodingV11, string(content))
+		require.Equal(t, 11, s.StateVersion)
 
 		require.Equal(t, 2, strings.Count(s.System.Provider.Config.Config["multiline_value"], "\n"))
 	}
@@ -392,3 +553,229 @@ func TestUpgradeFuncs(t *testing.T) {
 	require.Equal(t, "dhcp4", s.System.Network.Config.Interfaces[0].Addresses[0])
 	require.Equal(t, "dhcp6", s.System.Network.Config.Interfaces[0].Addresses[1])
 }
+
+// Test a v1 to v2 migration, and that the migrated state survives a save and reload.
+func TestUpgradeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	funcs := state.UpgradeFuncs{
+		nil,
+		func(lines []string) ([]string, error) {
+			for i, line := range lines {
+				lines[i] = strings.Replace(line, "OS.Title: ", "OS.Name: ", 1)
+			}
+
+			return lines, nil
+		},
+	}
+
+	var s state.State
+
+	err := state.Decode([]byte("#Version: 1\nOS.Title: IncusOS\nOS.RunningRelease: 202506241635\n"), funcs, &s)
+	require.NoError(t, err)
+	require.Empty(t, s.UnrecognizedFields)
+	require.Equal(t, 2, s.StateVersion)
+	require.Equal(t, "IncusOS", s.OS.Name)
+
+	content, err := state.Encode(&s)
+	require.NoError(t, err)
+	require.Equal(t, "#Version: 2\nOS.Name: IncusOS\nOS.RunningRelease: 202506241635\n", string(content))
+
+	var reloaded state.State
+
+	err = state.Decode(content, funcs, &reloaded)
+	require.NoError(t, err)
+	require.Equal(t, 2, reloaded.StateVersion)
+	require.Equal(t, s.OS, reloaded.OS)
+}
+
+// Test loading a state from a newer version without ever writing it back.
+func TestNewerVersion(t *testing.T) {
+	t.Parallel()
+
+	body := "#Version: 99\nOS.Name: IncusOS\nOS.FooBar: BizBaz\n"
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	s, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.True(t, s.NewerStateVersion)
+	require.Equal(t, 99, s.StateVersion)
+	require.Equal(t, "IncusOS", s.OS.Name)
+
+	s.OS.Name = "Changed"
+	require.NoError(t, s.Save())
+
+	// Imports must be refused too, rather than silently not being saved.
+	require.ErrorContains(t, s.Import(t.Context(), []byte("#Version: 8\nOS.Name: Imported\n")), "wasn't fully loaded")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, body, string(content))
+}
+
+// Test that a failed save leaves the previously saved state intact.
+func TestSavePartialWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	s, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+
+	s.OS.Name = "IncusOS"
+	require.NoError(t, s.Save())
+
+	// Simulate a crash mid-write by leaving a truncated temporary file behind.
+	require.NoError(t, os.WriteFile(path+".tmp", []byte("#Version: 8\nOS.Na"), 0o600))
+
+	s, err = state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.Equal(t, "IncusOS", s.OS.Name)
+
+	// Prevent the temporary file from being written, so the save fails part way.
+	require.NoError(t, os.Remove(path+".tmp"))
+	require.NoError(t, os.Mkdir(path+".tmp", 0o700))
+
+	s.OS.Name = "Broken"
+	require.Error(t, s.Save())
+
+	s, err = state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.Equal(t, "IncusOS", s.OS.Name)
+}
+
+// Test exporting a state and importing it into another one.
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	src, err := state.LoadOrCreate(filepath.Join(t.TempDir(), "state.txt"))
+	require.NoError(t, err)
+
+	src.OS.Name = "IncusOS"
+	src.System.Network.Config = &api.SystemNetworkConfig{DNS: &api.SystemNetworkDNS{Hostname: "server01"}}
+
+	body, err := src.Export(t.Context())
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	dst, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+
+	dst.OS.SystemIsReady = true
+	dst.System.Update.Config.Channel = "testing"
+
+	err = dst.Import(t.Context(), body)
+	require.NoError(t, err)
+	require.Equal(t, "IncusOS", dst.OS.Name)
+	require.Equal(t, "server01", dst.System.Network.Config.DNS.Hostname)
+	require.Equal(t, "stable", dst.System.Update.Config.Channel)
+	require.True(t, dst.OS.SystemIsReady)
+
+	// The imported state should have been saved.
+	saved, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.Equal(t, "IncusOS", saved.OS.Name)
+
+	// Incompatible snapshots should be refused.
+	require.Error(t, dst.Import(t.Context(), []byte("OS.Name: Other\n")))
+	require.Error(t, dst.Import(t.Context(), []byte("#Version: 99\nOS.Name: Other\n")))
+	require.ErrorContains(t, dst.Import(t.Context(), []byte("#Version: 8\nOS.Name: Other\nOS.RunningRelease: ../../1\n")), "OS.RunningRelease")
+	require.Equal(t, "IncusOS", dst.OS.Name)
+}
+
+// Test running callbacks after saving the state.
+func TestOnSave(t *testing.T) {
+	t.Parallel()
+
+	s, err := state.LoadOrCreate(filepath.Join(t.TempDir(), "state.txt"))
+	require.NoError(t, err)
+
+	saved := 0
+
+	s.OnSave(func(_ *state.State) {
+		panic("broken callback")
+	})
+
+	s.OnSave(func(cbState *state.State) {
+		require.Equal(t, s, cbState)
+
+		saved++
+	})
+
+	require.NoError(t, s.Save())
+	require.NoError(t, s.Save())
+	require.Equal(t, 2, saved)
+}
+
+// Test moving aside unparseable states on load.
+func TestLoadCorrupt(t *testing.T) {
+	t.Parallel()
+
+	body := "#Version: 8\nOS.Name\n"
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	s, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.Empty(t, s.OS.Name)
+	require.Equal(t, "stable", s.System.Update.Config.Channel)
+
+	corrupt, err := filepath.Glob(path + ".corrupt-*")
+	require.NoError(t, err)
+	require.Len(t, corrupt, 1)
+	require.Equal(t, corrupt[0], s.QuarantinedPath)
+
+	content, err := os.ReadFile(corrupt[0])
+	require.NoError(t, err)
+	require.Equal(t, body, string(content))
+}
+
+// Test keeping, but never writing back, states which parse but are invalid.
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	body := "#Version: 11\nOS.RunningRelease: ../../202506241635\nSystem.Security.Config.EncryptionRecoveryKeys[0]: ebbbibiu\n"
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	s, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.ErrorContains(t, s.InvalidStateError, "OS.RunningRelease")
+	require.Empty(t, s.QuarantinedPath)
+	require.Equal(t, []string{"ebbbibiu"}, s.System.Security.Config.EncryptionRecoveryKeys)
+
+	corrupt, err := filepath.Glob(path + ".corrupt-*")
+	require.NoError(t, err)
+	require.Empty(t, corrupt)
+
+	require.NoError(t, s.Save())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, body, string(content))
+}
+
+func TestLoadDuplicateApplication(t *testing.T) {
+	t.Parallel()
+
+	body := "#Version: 11\nApplications.Incus.State.Initialized: true\nApplications.Incus.State.Version: 202506241635\nApplications.Incus.State.Version: 202506241636\n"
+	path := filepath.Join(t.TempDir(), "state.txt")
+
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	s, err := state.LoadOrCreate(path)
+	require.NoError(t, err)
+	require.ErrorContains(t, s.InvalidStateError, "Applications.Incus.State.Version")
+	require.Empty(t, s.QuarantinedPath)
+
+	require.NoError(t, s.Save())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, body, string(content))
+}
@@ -29,8 +29,18 @@ type OS struct {
 type State struct {
 	path string
 
+	saveCallbacks      []func(*State)
+	saveCallbacksMutex sync.Mutex
+
+	eventsMutex sync.Mutex
+
+	duplicateFields []string
+
 	StateVersion       int      `json:"-"`
+	NewerStateVersion  bool     `json:"-"`
 	UnrecognizedFields []string `json:"-"`
+	InvalidStateError  error    `json:"-"`
+	QuarantinedPath    string   `json:"-"`
 
 	ShouldPerformInstall bool `json:"-"`
 
@@ -90,6 +100,9 @@ type State struct {
 		Storage          api.SystemStorage          `json:"storage"`
 	} `json:"system"`
 
+	// Recent actions performed by the daemon, oldest first.
+	Events []Event `json:"events"`
+
 	// Used to handle an edge case of a new network configuration being applied, but
 	// the system is rebooted before the new configuration can be confirmed. This helps
 	// ensure IncusOS will always be able to boot up with a known good configuration.
@@ -184,4 +184,16 @@ System.Network.Config.Proxy.Rules[%d].Target: direct
 
 		return lines, nil
 	},
+	// V9: Add Applications.*.State.InstalledAt, no conversion needed.
+	func(lines []string) ([]string, error) {
+		return lines, nil
+	},
+	// V10: Add the Events history, no conversion needed.
+	func(lines []string) ([]string, error) {
+		return lines, nil
+	},
+	// V11: Add System.Update.State.ImageSignature, no conversion needed.
+	func(lines []string) ([]string, error) {
+		return lines, nil
+	},
 }
@@ -0,0 +1,144 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var versionRegex = regexp.MustCompile(`^[A-Za-z0-9._+~-]*$`)
+
+// validate checks the invariants expected of a freshly loaded state.
+func (s *State) validate() error {
+	// A repeated key, such as a duplicated application entry, would silently override earlier values.
+	if len(s.duplicateFields) > 0 {
+		return fmt.Errorf("duplicate entries for %s", strings.Join(s.duplicateFields, ", "))
+	}
+
+	err := validateMaps(reflect.ValueOf(s).Elem(), "")
+	if err != nil {
+		return err
+	}
+
+	versions := map[string]string{
+		"OS.RunningRelease":  s.OS.RunningRelease,
+		"OS.NextRelease":     s.OS.NextRelease,
+		"SecureBoot.Version": s.SecureBoot.Version,
+	}
+
+	apps := reflect.ValueOf(s.Applications)
+	for i := range apps.NumField() {
+		appState := apps.Field(i).FieldByName("State")
+		if !appState.IsValid() || appState.Kind() != reflect.Struct {
+			return fmt.Errorf("application %s doesn't have a state", apps.Type().Field(i).Name)
+		}
+
+		version := appState.FieldByName("Version")
+		if !version.IsValid() || version.Kind() != reflect.String {
+			return fmt.Errorf("application %s doesn't have a version", apps.Type().Field(i).Name)
+		}
+
+		versions["Applications."+apps.Type().Field(i).Name+".State.Version"] = version.String()
+	}
+
+	for field, version := range versions {
+		if !versionRegex.MatchString(version) {
+			return fmt.Errorf("invalid version '%s' for %s", version, field)
+		}
+	}
+
+	return nil
+}
+
+// initializeMaps replaces any nil map in the persistent state with an empty one, as the encoder
+// doesn't write out empty maps and they come back as nil.
+func initializeMaps(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Map:
+		if v.IsNil() && v.CanSet() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+
+		// Map values aren't addressable, so update a copy and store it back.
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			initializeMaps(value)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			initializeMaps(v.Elem())
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			initializeMaps(v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() {
+			return
+		}
+
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" || field.Tag.Get("incusos") == "-" {
+				continue
+			}
+
+			initializeMaps(v.Field(i))
+		}
+	}
+}
+
+// validateMaps checks that all maps in the persistent state are initialized and that none of
+// them has an empty key, which the encoder couldn't write back.
+func validateMaps(v reflect.Value, path string) error {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Errorf("uninitialized map for %s", path)
+		}
+
+		for _, key := range v.MapKeys() {
+			if key.String() == "" {
+				return fmt.Errorf("empty key in %s", path)
+			}
+
+			err := validateMaps(v.MapIndex(key), fmt.Sprintf("%s[%s]", path, key.String()))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return validateMaps(v.Elem(), path)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			err := validateMaps(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() {
+			return nil
+		}
+
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" || field.Tag.Get("incusos") == "-" {
+				continue
+			}
+
+			err := validateMaps(v.Field(i), strings.TrimPrefix(path+"."+field.Name, "."))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package systemd
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/subprocess"
+)
+
+// KernelMessage represents a single kernel message from the journal.
+type KernelMessage struct {
+	Time     time.Time
+	Priority int
+	Message  string
+}
+
+// GetRecentJournalEntries returns the messages of the most recent journal entries of the
+// specified unit for the current boot, oldest first.
+func GetRecentJournalEntries(ctx context.Context, unit string, count int) ([]string, error) {
+	output, err := subprocess.RunCommandContext(ctx, "journalctl", "-b", "-u", unit, "-n", strconv.Itoa(count), "-o", "cat", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+
+	for line := range strings.Lines(output) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		ret = append(ret, strings.TrimSuffix(line, "\n"))
+	}
+
+	return ret, nil
+}
+
+// GetRecentKernelMessages returns the most recent kernel messages with a priority of warning
+// or higher for the current boot, oldest first.
+func GetRecentKernelMessages(ctx context.Context, count int) ([]KernelMessage, error) {
+	output, err := subprocess.RunCommandContext(ctx, "journalctl", "-b", "-k", "-p", "warning", "-n", strconv.Itoa(count), "-o", "json", "--output-fields=MESSAGE,PRIORITY", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseKernelMessages(output), nil
+}
+
+// parseKernelMessages parses the JSON output of journalctl, skipping any entry which can't be parsed.
+func parseKernelMessages(output string) []KernelMessage {
+	ret := []KernelMessage{}
+
+	for line := range strings.Lines(output) {
+		var entry struct {
+			Message   any    `json:"MESSAGE"`
+			Priority  string `json:"PRIORITY"`
+			Timestamp string `json:"__REALTIME_TIMESTAMP"`
+		}
+
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			continue
+		}
+
+		// Messages which aren't valid UTF-8 are encoded as an array of bytes, skip those.
+		message, ok := entry.Message.(string)
+		if !ok {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(entry.Timestamp, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		priority, err := strconv.Atoi(entry.Priority)
+		if err != nil {
+			continue
+		}
+
+		ret = append(ret, KernelMessage{
+			Time:     time.UnixMicro(timestamp),
+			Priority: priority,
+			Message:  message,
+		})
+	}
+
+	return ret
+}
@@ -0,0 +1,23 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKernelMessages(t *testing.T) {
+	t.Parallel()
+
+	messages := parseKernelMessages(`{"__REALTIME_TIMESTAMP":"1767225600000000","PRIORITY":"3","MESSAGE":"ata1: COMRESET failed (errno=-16)"}
+{"__REALTIME_TIMESTAMP":"1767225601000000","PRIORITY":"4","MESSAGE":[104,105,255]}
+not json
+{"__REALTIME_TIMESTAMP":"1767225602000000","PRIORITY":"4","MESSAGE":"CPU0: Core temperature above threshold"}
+`)
+
+	require.Equal(t, []KernelMessage{
+		{Time: time.UnixMicro(1767225600000000), Priority: 3, Message: "ata1: COMRESET failed (errno=-16)"},
+		{Time: time.UnixMicro(1767225602000000), Priority: 4, Message: "CPU0: Core temperature above threshold"},
+	}, messages)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -203,6 +204,46 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 	return nil
 }
 
+// ApplyAndSaveNetworkConfiguration applies the supplied network configuration, including any hardware
+// address filters, then records it in the state and saves it.
+func ApplyAndSaveNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error) error {
+	err := applyNetworkConfigurationWithFilters(ctx, s, networkCfg, timeout, refresh)
+	if err != nil {
+		s.AddEvent(state.EventTypeNetwork, "Failed to apply new network configuration: "+err.Error())
+
+		return err
+	}
+
+	s.AddEvent(state.EventTypeNetwork, "Applied new network configuration")
+
+	return s.Save()
+}
+
+// RollbackNetworkConfiguration restores the network configuration saved before the last change, then
+// records it in the state and saves it.
+func RollbackNetworkConfiguration(ctx context.Context, s *state.State, timeout time.Duration, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error) error {
+	err := applyNetworkConfigurationWithFilters(ctx, s, s.PriorNetworkConfig, timeout, refresh)
+	if err != nil {
+		s.AddEvent(state.EventTypeNetwork, "Failed to roll back to the prior network configuration: "+err.Error())
+
+		return err
+	}
+
+	s.AddEvent(state.EventTypeNetwork, "Rolled back to the prior network configuration")
+
+	return s.Save()
+}
+
+// applyNetworkConfigurationWithFilters applies the hardware address filters, then the network configuration.
+func applyNetworkConfigurationWithFilters(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error) error {
+	err := nftables.ApplyHwaddrFilters(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	return ApplyNetworkConfiguration(ctx, s, networkCfg, timeout, false, refresh, false)
+}
+
 // ValidateNetworkConfiguration performs some basic validation checks on the supplied network configuration.
 func ValidateNetworkConfiguration(networkCfg *api.SystemNetworkConfig, requireValidMAC bool) error {
 	if networkCfg == nil {
@@ -899,8 +940,49 @@ func resolveBridge(iface string) string {
 	return "_v" + iface
 }
 
+// IPAddressFilter defines which addresses of an interface are reported.
+type IPAddressFilter struct {
+	IPv4      bool
+	IPv6      bool
+	LinkLocal bool
+	ULA       bool
+}
+
+// DefaultIPAddressFilter reports all addresses other than link-local ones.
+var DefaultIPAddressFilter = IPAddressFilter{IPv4: true, IPv6: true, ULA: true}
+
+// Matches returns true if the address should be reported according to the filter.
+func (f IPAddressFilter) Matches(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	if (addr.Is4() && !f.IPv4) || (addr.Is6() && !f.IPv6) {
+		return false
+	}
+
+	if addr.IsLinkLocalUnicast() && !f.LinkLocal {
+		return false
+	}
+
+	// For IPv6, private addresses are the unique local ones (fc00::/7).
+	if addr.Is6() && addr.IsPrivate() && !f.ULA {
+		return false
+	}
+
+	return true
+}
+
 // GetIPAddresses returns any non-link-local address for an interface.
 func GetIPAddresses(ctx context.Context, iface string) ([]string, error) {
+	return GetFilteredIPAddresses(ctx, iface, DefaultIPAddressFilter)
+}
+
+// GetFilteredIPAddresses returns the addresses of an interface matching the provided filter.
+func GetFilteredIPAddresses(ctx context.Context, iface string, filter IPAddressFilter) ([]string, error) {
 	ipAddressRegex := regexp.MustCompile(`inet6? (.+)/\d+ `)
 
 	output, err := subprocess.RunCommandContext(ctx, "ip", "address", "show", resolveBridge(iface))
@@ -912,8 +994,7 @@ func GetIPAddresses(ctx context.Context, iface string) ([]string, error) {
 	matches := ipAddressRegex.FindAllStringSubmatch(output, -1)
 
 	for _, addr := range matches {
-		// Don't count link-local addresses.
-		if strings.HasPrefix(addr[1], "169.254.") || strings.HasPrefix(addr[1], "fe80:") {
+		if !filter.Matches(addr[1]) {
 			continue
 		}
 
@@ -808,3 +808,33 @@ func TestNetworkFileGeneration(t *testing.T) {
 	require.Equal(t, "22-management.network", cfgs[6].Name)
 	require.Equal(t, "[Match]\nName=management\n\n[Link]\nRequiredForOnline=yes\nRequiredFamilyForOnline=both\n\n[DHCP]\nClientIdentifier=mac\nRouteMetric=100\nUseMTU=true\n\n[DHCPv6]\nWithoutRA=solicit\n\n[Network]\nLinkLocalAddressing=ipv6\nIPv6AcceptRA=true\nDHCP=ipv4\n", cfgs[6].Contents)
 }
+
+func TestIPAddressFilter(t *testing.T) {
+	t.Parallel()
+
+	noULA := IPAddressFilter{IPv4: true, IPv6: true}
+	debug := IPAddressFilter{IPv4: true, IPv6: true, LinkLocal: true, ULA: true}
+	ipv4Only := IPAddressFilter{IPv4: true}
+
+	tests := []struct {
+		address  string
+		defaults bool
+		noULA    bool
+		debug    bool
+		ipv4Only bool
+	}{
+		{"10.0.0.1", true, true, true, true},
+		{"169.254.1.1", false, false, true, false},
+		{"2001:db8::1", true, true, true, false},
+		{"fd40:1234::10", true, false, true, false},
+		{"fe80::1", false, false, true, false},
+		{"invalid", false, false, false, false},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.defaults, DefaultIPAddressFilter.Matches(tc.address), tc.address)
+		require.Equal(t, tc.noULA, noULA.Matches(tc.address), tc.address)
+		require.Equal(t, tc.debug, debug.Matches(tc.address), tc.address)
+		require.Equal(t, tc.ipv4Only, ipv4Only.Matches(tc.address), tc.address)
+	}
+}
@@ -16,6 +16,16 @@ func SystemPowerOff(ctx context.Context) error {
 	return nil
 }
 
+// SystemPowerOffNoBlock triggers a system shutdown, returning as soon as systemd has queued it.
+func SystemPowerOffNoBlock(ctx context.Context) error {
+	_, err := subprocess.RunCommandContext(ctx, "systemctl", "--no-block", "poweroff")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SystemReboot triggers a system reboot.
 func SystemReboot(ctx context.Context) error {
 	_, err := subprocess.RunCommandContext(ctx, "systemctl", "reboot")
@@ -26,6 +36,16 @@ func SystemReboot(ctx context.Context) error {
 	return nil
 }
 
+// SystemRebootNoBlock triggers a system reboot, returning as soon as systemd has queued it.
+func SystemRebootNoBlock(ctx context.Context) error {
+	_, err := subprocess.RunCommandContext(ctx, "systemctl", "--no-block", "reboot")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SystemSuspend triggers a system suspend.
 func SystemSuspend(ctx context.Context) error {
 	_, err := subprocess.RunCommandContext(ctx, "systemctl", "suspend")
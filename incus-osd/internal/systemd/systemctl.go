@@ -2,6 +2,7 @@ package systemd
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/lxc/incus/v7/shared/subprocess"
@@ -20,33 +21,33 @@ func KillUnit(ctx context.Context, signal string, units ...string) error {
 	return nil
 }
 
-// StartUnit instructs systemd to start the provided unit(s).
+// StartUnit instructs systemd to start the provided unit(s), waiting for the job to complete.
 func StartUnit(ctx context.Context, units ...string) error {
 	args := []string{"start"} //nolint:prealloc
 	args = append(args, units...)
 
 	_, err := subprocess.RunCommandContext(ctx, "systemctl", args...)
 	if err != nil {
-		return err
+		return unitError(ctx, err, units...)
 	}
 
 	return nil
 }
 
-// RestartUnit instructs systemd to restart the provided unit(s).
+// RestartUnit instructs systemd to restart the provided unit(s), waiting for the job to complete.
 func RestartUnit(ctx context.Context, units ...string) error {
 	args := []string{"restart"} //nolint:prealloc
 	args = append(args, units...)
 
 	_, err := subprocess.RunCommandContext(ctx, "systemctl", args...)
 	if err != nil {
-		return err
+		return unitError(ctx, err, units...)
 	}
 
 	return nil
 }
 
-// StopUnit instructs systemd to stop the provided unit(s).
+// StopUnit instructs systemd to stop the provided unit(s), waiting for the job to complete.
 func StopUnit(ctx context.Context, units ...string) error {
 	args := []string{"stop"} //nolint:prealloc
 	args = append(args, units...)
@@ -67,12 +68,25 @@ func StopUnit(ctx context.Context, units ...string) error {
 
 	_, err := subprocess.RunCommandContext(ctx, "systemctl", args...)
 	if err != nil {
-		return err
+		return unitError(ctx, err, units...)
 	}
 
 	return nil
 }
 
+// unitError adds the current state of the unit(s) to a failed systemctl error, to help diagnose it.
+func unitError(ctx context.Context, err error, units ...string) error {
+	args := []string{"show", "--property=Id,ActiveState,SubState,Result"} //nolint:prealloc
+	args = append(args, units...)
+
+	output, showErr := subprocess.RunCommandContext(ctx, "systemctl", args...)
+	if showErr != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w (%s)", err, strings.Join(strings.Fields(output), " "))
+}
+
 // IsActive returns a boolean indicating if the specified unit is in an active state.
 func IsActive(ctx context.Context, unit string) bool {
 	result, err := subprocess.RunCommandContext(ctx, "systemctl", "is-active", unit)
@@ -101,3 +115,52 @@ func IsSystemRunning(ctx context.Context) string {
 
 	return strings.TrimSuffix(result, "\n")
 }
+
+// UnitStatus holds the current state of a systemd unit.
+type UnitStatus struct {
+	Name        string
+	Load        string
+	Active      string
+	Sub         string
+	Description string
+}
+
+// ListUnitStatuses returns the status of all loaded units matching the provided patterns, or of all loaded units if none are provided.
+func ListUnitStatuses(ctx context.Context, patterns ...string) ([]UnitStatus, error) {
+	args := []string{"list-units", "--all", "--plain", "--no-legend", "--full"} //nolint:prealloc
+	args = append(args, patterns...)
+
+	output, err := subprocess.RunCommandContext(ctx, "systemctl", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseUnitStatuses(output), nil
+}
+
+func parseUnitStatuses(output string) []UnitStatus {
+	ret := []UnitStatus{}
+
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+
+		// Failed units may be prefixed with a status marker.
+		if len(fields) > 0 && fields[0] == "●" {
+			fields = fields[1:]
+		}
+
+		if len(fields) < 4 {
+			continue
+		}
+
+		ret = append(ret, UnitStatus{
+			Name:        fields[0],
+			Load:        fields[1],
+			Active:      fields[2],
+			Sub:         fields[3],
+			Description: strings.Join(fields[4:], " "),
+		})
+	}
+
+	return ret
+}
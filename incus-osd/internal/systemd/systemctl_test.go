@@ -0,0 +1,22 @@
+package systemd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnitStatuses(t *testing.T) {
+	t.Parallel()
+
+	output := `incus-osd.service loaded active running Incus OS daemon
+● incus.service loaded failed failed Incus - Daemon
+systemd-networkd.service loaded active running Network Configuration
+`
+
+	require.Equal(t, []UnitStatus{
+		{Name: "incus-osd.service", Load: "loaded", Active: "active", Sub: "running", Description: "Incus OS daemon"},
+		{Name: "incus.service", Load: "loaded", Active: "failed", Sub: "failed", Description: "Incus - Daemon"},
+		{Name: "systemd-networkd.service", Load: "loaded", Active: "active", Sub: "running", Description: "Network Configuration"},
+	}, parseUnitStatuses(output))
+}
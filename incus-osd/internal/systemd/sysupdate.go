@@ -6,10 +6,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/foxboron/go-uefi/authenticode"
 	"github.com/lxc/incus/v7/shared/subprocess"
@@ -21,21 +24,86 @@ import (
 // ErrReleaseNotFound is returned when the os-release file can't be located.
 var ErrReleaseNotFound = errors.New("couldn't determine current OS release")
 
+// ErrImageSignature is returned when an OS update isn't signed by a trusted certificate.
+var ErrImageSignature = errors.New("image signature verification failed")
+
+// CurrentReleaseCacheTTL is how long GetCurrentReleaseInfo caches a successfully read release.
+var CurrentReleaseCacheTTL = 5 * time.Minute
+
+var (
+	currentReleaseMutex  sync.Mutex
+	currentRelease       ReleaseInfo
+	currentReleaseExpiry time.Time
+)
+
+// ReleaseInfo describes the currently running OS release.
+type ReleaseInfo struct {
+	Name      string    // Operating system name, such as "IncusOS".
+	OSID      string    // Operating system identifier from os-release, not an image or build identifier.
+	Version   string    // Image version, which is also the UTC timestamp of the build.
+	BuildDate time.Time // Build date, parsed from the image version.
+	Channel   string    // Update channel the system is following. Not part of os-release, so left for callers to fill from the state.
+}
+
 // GetCurrentRelease returns the current NAME and IMAGE_VERSION from the os-release file.
-func GetCurrentRelease(_ context.Context) (string, string, error) {
+// The result is cached for CurrentReleaseCacheTTL or until InvalidateCurrentRelease is called.
+func GetCurrentRelease(ctx context.Context) (string, string, error) {
+	info, err := GetCurrentReleaseInfo(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	return info.Name, info.Version, nil
+}
+
+// GetCurrentReleaseInfo returns information about the current release from the os-release file.
+// The result is cached for CurrentReleaseCacheTTL or until InvalidateCurrentRelease is called.
+func GetCurrentReleaseInfo(ctx context.Context) (ReleaseInfo, error) {
+	currentReleaseMutex.Lock()
+	defer currentReleaseMutex.Unlock()
+
+	if time.Now().Before(currentReleaseExpiry) {
+		return currentRelease, nil
+	}
+
+	info, err := readCurrentRelease(ctx)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	currentRelease = info
+	currentReleaseExpiry = time.Now().Add(CurrentReleaseCacheTTL)
+
+	return info, nil
+}
+
+// InvalidateCurrentRelease forces the next call to GetCurrentReleaseInfo to re-read the os-release file.
+func InvalidateCurrentRelease() {
+	currentReleaseMutex.Lock()
+	defer currentReleaseMutex.Unlock()
+
+	currentReleaseExpiry = time.Time{}
+}
+
+// readCurrentRelease reads the current release information from the os-release file.
+func readCurrentRelease(_ context.Context) (ReleaseInfo, error) {
 	// Open the os-release file.
 	fd, err := os.Open("/lib/os-release")
 	if err != nil {
-		return "", "", err
+		return ReleaseInfo{}, err
 	}
 
 	defer fd.Close()
 
-	name := ""
-	version := ""
+	return parseOSRelease(fd)
+}
+
+// parseOSRelease parses the content of an os-release file.
+func parseOSRelease(r io.Reader) (ReleaseInfo, error) {
+	info := ReleaseInfo{}
 
 	// Prepare reader.
-	fdScan := bufio.NewScanner(fd)
+	fdScan := bufio.NewScanner(r)
 	for fdScan.Scan() {
 		line := fdScan.Text()
 		fields := strings.SplitN(line, "=", 2)
@@ -46,18 +114,26 @@ func GetCurrentRelease(_ context.Context) (string, string, error) {
 
 		switch fields[0] {
 		case "NAME":
-			name = strings.Trim(fields[1], "\"")
+			info.Name = strings.Trim(fields[1], "\"")
+		case "ID":
+			info.OSID = strings.Trim(fields[1], "\"")
 		case "IMAGE_VERSION":
-			version = strings.Trim(fields[1], "\"")
+			info.Version = strings.Trim(fields[1], "\"")
 		default:
 		}
 	}
 
-	if name != "" && version != "" {
-		return name, version, nil
+	if info.Name == "" || info.Version == "" {
+		return ReleaseInfo{}, ErrReleaseNotFound
+	}
+
+	// Release versions are the UTC timestamp of the build, such as "202501011200".
+	buildDate, err := time.Parse("200601021504", info.Version)
+	if err == nil {
+		info.BuildDate = buildDate
 	}
 
-	return "", "", ErrReleaseNotFound
+	return info, nil
 }
 
 // ApplySystemUpdate instructs systemd-sysupdate to apply any pending update.
@@ -125,7 +201,7 @@ func ApplySystemUpdate(ctx context.Context, version string) error {
 	// Get the trusted certificate that matches the verity certificate fingerprint.
 	trustedCert, err := getTrustedVerityCertificate(ctx, metadata.CertificateFingerprint)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrImageSignature, err)
 	}
 
 	// Verify the UKI image.
@@ -142,13 +218,13 @@ func ApplySystemUpdate(ctx context.Context, version string) error {
 
 	_, err = ukiAuthenticode.Verify(trustedCert)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrImageSignature, err)
 	}
 
 	// Verify the usr image PKCS7 signature.
 	err = verifySignature(metadata.Signature, metadata.RootHash, trustedCert)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrImageSignature, err)
 	}
 
 	// Check if the Secure Boot key has changed; if it has apply the necessary updates.
@@ -182,5 +258,7 @@ func ApplySystemUpdate(ctx context.Context, version string) error {
 	// Flush all writes to get a consistent ESP if the system gets forcefully rebooted by the user.
 	unix.Sync()
 
+	InvalidateCurrentRelease()
+
 	return nil
 }
@@ -0,0 +1,24 @@
+package systemd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	t.Parallel()
+
+	info, err := parseOSRelease(strings.NewReader(`PRETTY_NAME="IncusOS 202501021530"
+NAME="IncusOS"
+ID="IncusOS"
+IMAGE_VERSION=202501021530
+`))
+	require.NoError(t, err)
+	require.Equal(t, ReleaseInfo{Name: "IncusOS", OSID: "IncusOS", Version: "202501021530", BuildDate: time.Date(2025, 1, 2, 15, 30, 0, 0, time.UTC)}, info)
+
+	_, err = parseOSRelease(strings.NewReader("NAME=IncusOS\n"))
+	require.ErrorIs(t, err, ErrReleaseNotFound)
+}
@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// How long without any log record before the activity indicator is highlighted.
+const inactivityWarning = 10 * time.Minute
+
+// getActivityIndicator returns how long ago the last log record was written, along with the
+// color to show it in, so a hung daemon can be told apart from a quiet one.
+func (t *TUI) getActivityIndicator() (string, tcell.Color) {
+	since := time.Since(time.Unix(0, t.lastWrite.Load())).Truncate(time.Second)
+
+	color := t.theme.headerColor
+	if since >= inactivityWarning {
+		color = t.theme.warningColor
+	}
+
+	return "Last activity: " + since.String() + " ago", color
+}
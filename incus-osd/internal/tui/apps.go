@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
+)
+
+// showApplicationActions displays a dialog allowing the user to restart or remove one of the installed applications.
+func (t *TUI) showApplicationActions() {
+	apps, err := applications.GetInstalled(context.Background(), t.state)
+	if err != nil {
+		t.showMessage("Applications", "[red]Failed to list the installed applications:[white] "+tview.Escape(err.Error()))
+
+		return
+	}
+
+	if len(apps) == 0 {
+		t.showMessage("Applications", "No applications are currently installed.")
+
+		return
+	}
+
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name())
+	}
+
+	slices.Sort(names)
+
+	selected := names[0]
+
+	form := tview.NewForm()
+	form.AddDropDown("Application", names, 0, func(name string, _ int) {
+		selected = name
+	})
+
+	form.AddButton("Restart", func() {
+		t.closeDialog()
+
+		go t.restartApplication(selected)
+	})
+
+	form.AddButton("Uninstall", func() {
+		t.closeDialog()
+
+		go t.uninstallApplication(selected)
+	})
+
+	form.AddButton("Cancel", t.closeDialog)
+	form.SetCancelFunc(t.closeDialog)
+	form.SetTitle(" Applications ").SetBorder(true)
+
+	t.showDialog(form, 50, 7)
+}
+
+// restartApplication asks the user to confirm restarting the given application, then restarts it.
+func (t *TUI) restartApplication(name string) {
+	confirmed, err := t.DisplayConfirm("Restart application", "Are you sure you want to restart "+name+"?")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("Application restart requested from the console", "name", name)
+
+	ctx := context.Background()
+
+	modal := t.DisplayBusyModal("Restart application", "Restarting "+name+"...")
+
+	app, err := applications.Load(ctx, t.state, name)
+	if err == nil {
+		err = app.Restart(ctx)
+	}
+
+	modal.Done()
+
+	if err != nil {
+		slog.Error("Failed to restart application '"+name+"'", "err", err)
+		t.showMessage("Restart application", "[red]Failed to restart "+name+":[white] "+tview.Escape(err.Error()))
+
+		return
+	}
+
+	t.showMessage("Restart application", name+" has been restarted.")
+	t.requestRedraw()
+}
+
+// uninstallApplication asks the user to confirm removing the given application, then removes it along with its local data.
+func (t *TUI) uninstallApplication(name string) {
+	confirmed, err := t.DisplayConfirm("Uninstall application", "Are you sure you want to uninstall "+name+"?\n\n[red]All of its local data will be lost.[white]")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("Application removal requested from the console", "name", name)
+
+	modal := t.DisplayBusyModal("Uninstall application", "Removing "+name+"...")
+
+	// The state is saved on success, which refreshes the applications list and footer.
+	err = applications.UninstallApplication(context.Background(), t.state, name)
+
+	modal.Done()
+
+	if err != nil {
+		slog.Error("Failed to remove application '"+name+"'", "err", err)
+		t.showMessage("Uninstall application", "[red]Failed to remove "+name+":[white] "+tview.Escape(err.Error()))
+
+		return
+	}
+
+	t.showMessage("Uninstall application", name+" has been removed.")
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"log/slog"
+	"regexp"
+
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/internal/state"
+)
+
+// Update channel names are provided by the update server, so only check they're well formed.
+var updateChannelRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// showChannelPrompt prompts the user for a different update channel to switch to.
+// This must not be called from a TUI event handler.
+func (t *TUI) showChannelPrompt() {
+	current := t.state.System.Update.Config.Channel
+
+	channel, err := t.promptChannel(current)
+	if err != nil || channel == "" || channel == current {
+		return
+	}
+
+	if !updateChannelRegex.MatchString(channel) {
+		t.showMessage("Update channel", "[red]Invalid update channel name:[white] "+tview.Escape(channel))
+
+		return
+	}
+
+	t.switchChannel(channel)
+}
+
+// promptChannel asks the user for the name of an update channel, blocking until it's been entered.
+// An empty string is returned if the dialog was cancelled.
+func (t *TUI) promptChannel(current string) (string, error) {
+	result := make(chan string, 1)
+	channel := current
+
+	form := tview.NewForm()
+	form.AddInputField("Channel", current, 30, nil, func(text string) {
+		channel = text
+	})
+
+	form.AddButton("Switch", func() {
+		t.closeDialog()
+		result <- channel
+	})
+
+	form.SetCancelFunc(func() {
+		t.closeDialog()
+		result <- ""
+	})
+
+	form.SetTitle(" Update channel ").SetBorder(true)
+
+	err := t.openDialog(form, 50, 7)
+	if err != nil {
+		return "", err
+	}
+
+	return <-result, nil
+}
+
+// switchChannel asks the user to confirm switching to the given update channel, then records it.
+// Any update from the new channel is only applied by the next update check.
+func (t *TUI) switchChannel(channel string) {
+	confirmed, err := t.DisplayConfirm("Update channel", "Are you sure you want to switch to the "+channel+" update channel?\n\nUpdates from it will be applied on the next update check.")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("Update channel change requested from the console", "channel", channel)
+
+	t.state.System.Update.Config.Channel = channel
+	t.state.System.Update.State.Status = "Switched to the " + channel + " channel, waiting for the next update check"
+	t.state.AddEvent(state.EventTypeUpdate, "Switched to the "+channel+" update channel")
+
+	err = t.state.Save()
+	if err != nil {
+		slog.Error("Failed to save the new update channel", "err", err)
+		t.showMessage("Update channel", "[red]Failed to switch update channel:[white] "+tview.Escape(err.Error()))
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Default format of the clock shown in the header.
+const defaultClockFormat = "2006-01-02 15:04:05 MST"
+
+// getClock returns the current time, as shown in the header.
+func (t *TUI) getClock() string {
+	return time.Now().In(t.config.clockLocation).Format(t.config.clockFormat)
+}
+
+// getClockPlaceholder returns blank text reserving the room of the clock in the header, as the
+// clock itself is drawn separately so it can be updated without re-drawing the whole screen.
+func (t *TUI) getClockPlaceholder() string {
+	return strings.Repeat(" ", tview.TaggedStringWidth(tview.Escape(t.getClock())))
+}
+
+// drawClock draws the current time in the top right corner of the header.
+func (t *TUI) drawClock(screen tcell.Screen) {
+	x, y, width, _ := t.frame.GetInnerRect()
+	tview.Print(screen, tview.Escape(t.getClock()), x, y, width, tview.AlignRight, t.theme.headerColor)
+}
+
+// tickClock refreshes the header clock at the start of every second.
+func (t *TUI) tickClock(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(time.Now().Truncate(time.Second).Add(time.Second))):
+		}
+
+		t.app.QueueUpdate(func() {
+			t.drawClock(t.screen)
+			t.screen.Show()
+		})
+	}
+}
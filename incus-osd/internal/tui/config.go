@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// Supported TUI layouts.
+const (
+	layoutLogs      = "logs"
+	layoutDashboard = "dashboard"
+)
+
+// config holds the tunable options of the TUI. Defaults can be overridden through
+// environment variables set on the incus-osd service.
+type config struct {
+	// Either "logs" (log view with status footer) or "dashboard" (status page with toggled log view).
+	layout string
+
+	// Maximum number of log lines retained in memory.
+	logLines int
+
+	// How often the whole screen is re-drawn.
+	redrawInterval time.Duration
+
+	// How often the console is forcefully cleared, zero to disable.
+	clearInterval time.Duration
+
+	// Name of the color theme to use.
+	theme string
+
+	// Whether to show the MAC address of each interface next to its IP addresses.
+	showMACs bool
+
+	// Whether to show the system's serial number.
+	showSerial bool
+
+	// Which IP addresses are shown for each interface.
+	ipFilter systemd.IPAddressFilter
+
+	// Language of the labels, English if not set or unsupported.
+	language string
+
+	// Endpoint probed to check connectivity, defaulting to the update server.
+	connectivityURL string
+
+	// How often connectivity is checked.
+	connectivityInterval time.Duration
+
+	// Whether to show a sparkline of the recent CPU usage.
+	showSparkline bool
+
+	// Whether log lines older than dimAfter are dimmed.
+	dimLogs  bool
+	dimAfter time.Duration
+
+	// Timezone and layout of the header clock.
+	clockLocation *time.Location
+	clockFormat   string
+}
+
+// loadConfig returns the TUI configuration, applying any overrides from the environment.
+// Serial consoles default to redrawing less often and never clearing, as each full repaint is slow.
+func loadConfig(serialConsole bool) config {
+	cfg := config{
+		layout:               layoutLogs,
+		logLines:             5000,
+		redrawInterval:       5 * time.Second,
+		clearInterval:        time.Minute,
+		theme:                os.Getenv("INCUSOS_TUI_THEME"),
+		ipFilter:             systemd.DefaultIPAddressFilter,
+		language:             getLanguage(),
+		connectivityURL:      os.Getenv("INCUSOS_TUI_CONNECTIVITY_URL"),
+		connectivityInterval: time.Minute,
+		dimAfter:             30 * time.Second,
+		clockLocation:        time.UTC,
+		clockFormat:          defaultClockFormat,
+	}
+
+	if serialConsole {
+		cfg.redrawInterval = 30 * time.Second
+		cfg.clearInterval = 0
+	}
+
+	if os.Getenv("INCUSOS_TUI_LAYOUT") == layoutDashboard {
+		cfg.layout = layoutDashboard
+	}
+
+	logLines, err := strconv.Atoi(os.Getenv("INCUSOS_TUI_LOG_LINES"))
+	if err == nil && logLines > 0 {
+		cfg.logLines = logLines
+	}
+
+	redrawInterval, err := time.ParseDuration(os.Getenv("INCUSOS_TUI_REDRAW_INTERVAL"))
+	if err == nil && redrawInterval > 0 {
+		cfg.redrawInterval = redrawInterval
+	}
+
+	showMACs, err := strconv.ParseBool(os.Getenv("INCUSOS_TUI_SHOW_MAC"))
+	if err == nil {
+		cfg.showMACs = showMACs
+	}
+
+	showSerial, err := strconv.ParseBool(os.Getenv("INCUSOS_TUI_SHOW_SERIAL"))
+	if err == nil {
+		cfg.showSerial = showSerial
+	}
+
+	// A comma separated list of the address types to show, such as "ipv4,ipv6,ula,link-local".
+	ipFilter := os.Getenv("INCUSOS_TUI_IP_FILTER")
+	if ipFilter != "" {
+		cfg.ipFilter = parseIPFilter(ipFilter)
+	}
+
+	clearInterval, err := time.ParseDuration(os.Getenv("INCUSOS_TUI_CLEAR_INTERVAL"))
+	if err == nil && clearInterval >= 0 {
+		cfg.clearInterval = clearInterval
+	}
+
+	connectivityInterval, err := time.ParseDuration(os.Getenv("INCUSOS_TUI_CONNECTIVITY_INTERVAL"))
+	if err == nil && connectivityInterval > 0 {
+		cfg.connectivityInterval = connectivityInterval
+	}
+
+	showSparkline, err := strconv.ParseBool(os.Getenv("INCUSOS_TUI_SPARKLINE"))
+	if err == nil {
+		cfg.showSparkline = showSparkline
+	}
+
+	dimLogs, err := strconv.ParseBool(os.Getenv("INCUSOS_TUI_DIM_LOGS"))
+	if err == nil {
+		cfg.dimLogs = dimLogs
+	}
+
+	dimAfter, err := time.ParseDuration(os.Getenv("INCUSOS_TUI_DIM_AFTER"))
+	if err == nil && dimAfter > 0 {
+		cfg.dimAfter = dimAfter
+	}
+
+	// Either "local" for the system's timezone, or an IANA timezone name such as "Europe/Paris".
+	timezone := os.Getenv("INCUSOS_TUI_TIMEZONE")
+	if timezone != "" {
+		location, err := parseTimezone(timezone)
+		if err != nil {
+			slog.Warn("Invalid TUI timezone, falling back to UTC", "timezone", timezone, "err", err)
+		} else {
+			cfg.clockLocation = location
+		}
+	}
+
+	clockFormat := os.Getenv("INCUSOS_TUI_CLOCK_FORMAT")
+	if clockFormat != "" {
+		cfg.clockFormat = clockFormat
+	}
+
+	return cfg
+}
+
+// parseTimezone returns the location matching a timezone name, with "local" being the system's timezone.
+func parseTimezone(name string) (*time.Location, error) {
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// parseIPFilter parses a comma separated list of address types to show into an IP address filter.
+func parseIPFilter(value string) systemd.IPAddressFilter {
+	filter := systemd.IPAddressFilter{}
+
+	for field := range strings.SplitSeq(value, ",") {
+		switch strings.TrimSpace(field) {
+		case "ipv4":
+			filter.IPv4 = true
+		case "ipv6":
+			filter.IPv6 = true
+		case "ula":
+			filter.ULA = true
+		case "link-local":
+			filter.LinkLocal = true
+		default:
+		}
+	}
+
+	return filter
+}
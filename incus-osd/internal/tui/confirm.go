@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"github.com/rivo/tview"
+)
+
+// DisplayConfirm asks the user to confirm an action, blocking until a choice has been made.
+// It returns true if the user selected "OK". This must not be called from a TUI event handler.
+func (t *TUI) DisplayConfirm(title string, msg string) (bool, error) {
+	result := make(chan bool, 1)
+
+	modal := tview.NewModal().
+		SetText(t.colorize(msg)).
+		AddButtons([]string{"OK", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			t.closeDialog()
+			result <- label == "OK"
+		})
+	modal.SetTitle(" " + title + " ")
+
+	err := t.openDialog(modal, 0, 0)
+	if err != nil {
+		return false, err
+	}
+
+	return <-result, nil
+}
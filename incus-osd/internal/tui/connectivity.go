@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Endpoint probed for connectivity when neither the TUI nor the provider configure one.
+const defaultConnectivityURL = "https://images.linuxcontainers.org/os"
+
+// How long a single connectivity probe may take before the system is considered offline.
+const connectivityTimeout = 10 * time.Second
+
+// connectivityStatus holds the result of the most recent connectivity probe.
+type connectivityStatus struct {
+	url    string
+	online bool
+	err    error
+}
+
+// getConnectivityURL returns the endpoint to probe, defaulting to the provider's update server.
+func (t *TUI) getConnectivityURL() string {
+	if t.config.connectivityURL != "" {
+		return t.config.connectivityURL
+	}
+
+	serverURL := t.state.System.Provider.Config.Config["server_url"]
+	if serverURL != "" {
+		return serverURL
+	}
+
+	return defaultConnectivityURL
+}
+
+// watchConnectivity periodically probes the connectivity endpoint, caching the result for the footer.
+func (t *TUI) watchConnectivity(ctx context.Context) {
+	for {
+		// Nothing is reachable until the network has been configured after install.
+		if !t.state.ShouldPerformInstall {
+			status := probeConnectivity(ctx, t.getConnectivityURL())
+
+			prior := t.connectivity.Swap(&status)
+			if prior == nil || prior.online != status.online {
+				if !status.online {
+					slog.Warn("Connectivity check failed", "url", status.url, "err", status.err)
+				}
+
+				t.requestRedraw()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(t.config.connectivityInterval):
+		}
+	}
+}
+
+// probeConnectivity checks whether the endpoint can be reached. Any HTTP response, including
+// errors, confirms that DNS resolution and routing work.
+func probeConnectivity(ctx context.Context, url string) connectivityStatus {
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return connectivityStatus{url: url, err: err}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return connectivityStatus{url: url, err: err}
+	}
+
+	_ = resp.Body.Close()
+
+	return connectivityStatus{url: url, online: true}
+}
+
+// getConnectivityStatus returns the result of the last connectivity probe, for display in the footer.
+func (t *TUI) getConnectivityStatus() string {
+	status := t.connectivity.Load()
+	if status == nil {
+		return "checking..."
+	}
+
+	if !status.online {
+		return colorTag(t.theme.errorColor) + "offline[white] (unable to reach " + tview.Escape(status.url) + ")"
+	}
+
+	return "[green]online[white] (" + tview.Escape(status.url) + ")"
+}
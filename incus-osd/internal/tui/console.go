@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// isSerialConsole returns true if the kernel's active consoles are all serial devices. If the
+// active consoles can't be determined, a graphical console is assumed.
+func isSerialConsole() bool {
+	content, err := os.ReadFile("/sys/class/tty/console/active")
+	if err != nil {
+		return false
+	}
+
+	return parseSerialConsole(string(content))
+}
+
+// parseSerialConsole returns true if none of the listed consoles is a virtual terminal.
+func parseSerialConsole(active string) bool {
+	consoles := strings.Fields(active)
+	if len(consoles) == 0 {
+		return false
+	}
+
+	for _, console := range consoles {
+		// Virtual terminals are named tty0, tty1, etc.
+		name, found := strings.CutPrefix(console, "tty")
+		if found && name != "" && name[0] >= '0' && name[0] <= '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canDisplayBorders returns true if the screen can natively render the box-drawing characters used for borders.
+func canDisplayBorders(screen tcell.Screen) bool {
+	for _, r := range []rune{tview.Borders.Horizontal, tview.Borders.Vertical, tview.Borders.TopLeft, tview.Borders.TopRight, tview.Borders.BottomLeft, tview.Borders.BottomRight} {
+		if !screen.CanDisplay(r, false) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// useASCIIBorders replaces the box-drawing characters used for borders with plain ASCII,
+// which renders reliably on serial terminals.
+func useASCIIBorders() {
+	tview.Borders.Horizontal = '-'
+	tview.Borders.Vertical = '|'
+	tview.Borders.TopLeft = '+'
+	tview.Borders.TopRight = '+'
+	tview.Borders.BottomLeft = '+'
+	tview.Borders.BottomRight = '+'
+
+	tview.Borders.LeftT = '+'
+	tview.Borders.RightT = '+'
+	tview.Borders.TopT = '+'
+	tview.Borders.BottomT = '+'
+	tview.Borders.Cross = '+'
+
+	tview.Borders.HorizontalFocus = '='
+	tview.Borders.VerticalFocus = '|'
+	tview.Borders.TopLeftFocus = '+'
+	tview.Borders.TopRightFocus = '+'
+	tview.Borders.BottomLeftFocus = '+'
+	tview.Borders.BottomRightFocus = '+'
+}
+
+// newProgressBar returns a progress bar suitable for the current console.
+func (t *TUI) newProgressBar() *ProgressBar {
+	progressBar := NewProgressBar()
+
+	if t.serialConsole {
+		progressBar.SetFilledRune('#')
+		progressBar.SetEmptyRune(' ')
+	}
+
+	return progressBar
+}
@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// Words which flag a state field as holding a secret, to be redacted from diagnostics bundles.
+var secretFieldWords = []string{"Key", "Password", "Secret", "Token"}
+
+// State fields which match secretFieldWords but only hold a status.
+var nonSecretFields = []string{"EncryptionKeyStatus", "EncryptionRecoveryKeysRetrieved", "HaveNodeKey"}
+
+// State maps holding free-form configuration, such as provider or service settings, which are always redacted.
+var secretMapFields = []string{"ClientConfig", "Config", "Seeds"}
+
+// exportDiagnostics writes a compressed archive with the information most useful for support to
+// a timestamped file, also copying it to the first usable removable device, if any.
+func (t *TUI) exportDiagnostics() {
+	modal := t.DisplayBusyModal("Diagnostics", "Collecting diagnostics...")
+
+	filename := "incus-osd-diagnostics-" + time.Now().Format("20060102-150405") + ".tar.gz"
+	path := filepath.Join(logExportDir, filename)
+
+	slog.Info("Diagnostics bundle requested from the console")
+
+	err := t.writeDiagnostics(path)
+	if err != nil {
+		modal.Done()
+		t.showMessage("Diagnostics", "[red]Failed to collect diagnostics:[white] "+tview.Escape(err.Error()))
+
+		return
+	}
+
+	message := "Diagnostics written to " + path
+
+	device, err := exportToRemovable(filename, func(dest string) error {
+		return copyFile(path, dest)
+	})
+	if err != nil {
+		message += "\n\n[red]Failed to copy diagnostics to removable device:[white] " + tview.Escape(err.Error())
+	} else if device != "" {
+		message += "\n\nDiagnostics copied to " + filename + " on " + device
+	}
+
+	modal.Done()
+	t.showMessage("Diagnostics", message)
+}
+
+// writeDiagnostics writes a compressed archive with the retained logs, the redacted state, unit
+// statuses as well as network and storage information to the provided path.
+func (t *TUI) writeDiagnostics(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range []string{"logs.txt", "state.txt", "units.txt", "network.txt", "storage.txt", "events.txt"} {
+		content, err := t.getDiagnosticsFile(name)
+		if err != nil {
+			// Record the failure rather than leaving out the whole bundle.
+			content = "Failed to collect " + name + ": " + err.Error() + "\n"
+		}
+
+		err = tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(content)),
+			ModTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(tw, content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// getDiagnosticsFile returns the content of a single file of the diagnostics bundle.
+func (t *TUI) getDiagnosticsFile(name string) (string, error) {
+	switch name {
+	case "logs.txt":
+		var sb strings.Builder
+
+		for _, entry := range t.logs.Entries() {
+			sb.WriteString(stripColorTags(entry.text))
+		}
+
+		return sb.String(), nil
+	case "state.txt":
+		body, err := t.state.Export(context.Background())
+		if err != nil {
+			return "", err
+		}
+
+		return redactState(string(body)), nil
+	case "units.txt":
+		units, err := systemd.ListUnitStatuses(context.Background())
+		if err != nil {
+			return "", err
+		}
+
+		var sb strings.Builder
+
+		for _, unit := range units {
+			fmt.Fprintf(&sb, "%-60s %-10s %-10s %-10s %s\n", unit.Name, unit.Load, unit.Active, unit.Sub, unit.Description)
+		}
+
+		return sb.String(), nil
+	case "network.txt":
+		return t.stripThemeColors(t.renderNetworkView()), nil
+	case "storage.txt":
+		content, err := t.renderStorageView()
+		if err != nil {
+			return "", err
+		}
+
+		return t.stripThemeColors(content), nil
+	case "events.txt":
+		return t.stripThemeColors(t.renderEventsView()), nil
+	default:
+		return "", fmt.Errorf("unknown diagnostics file '%s'", name)
+	}
+}
+
+// redactState replaces the value of any state field which may hold a secret.
+func redactState(body string) string {
+	var sb strings.Builder
+
+	for line := range strings.Lines(body) {
+		key, _, ok := strings.Cut(line, ": ")
+		if !ok {
+			sb.WriteString(line)
+
+			continue
+		}
+
+		if isSecretStateKey(key) {
+			line = key + ": <redacted>\n"
+		}
+
+		sb.WriteString(line)
+	}
+
+	return sb.String()
+}
+
+// isSecretStateKey returns whether the provided state key, such as "System.Provider.Config.Config[token]",
+// may hold a secret, based on its field name, the key of any map it is part of or the map itself.
+func isSecretStateKey(key string) bool {
+	isSecret := func(name string, anyCase bool) bool {
+		return slices.ContainsFunc(secretFieldWords, func(word string) bool {
+			if anyCase {
+				return strings.Contains(strings.ToLower(name), strings.ToLower(word))
+			}
+
+			return strings.Contains(name, word)
+		})
+	}
+
+	fields := strings.Split(key, ".")
+	for i, field := range fields {
+		name, index, isIndexed := strings.Cut(field, "[")
+		if isIndexed {
+			// Map keys are free-form, so match them regardless of case.
+			if slices.Contains(secretMapFields, name) || isSecret(strings.TrimSuffix(index, "]"), true) {
+				return true
+			}
+		}
+
+		// Only consider the name of the field itself, without any index or parent.
+		if i == len(fields)-1 && !slices.Contains(nonSecretFields, name) && isSecret(name, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyFile copies the content of the file at src to dest.
+func copyFile(src string, dest string) error {
+	in, err := os.Open(src) // #nosec G304
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"strings"
+	"time"
+)
+
+// dimLogLine returns the rendered line dimmed if its entry is older than the dimming threshold.
+func (t *TUI) dimLogLine(line string, entry logEntry, now time.Time) string {
+	if !t.dimLogs.Load() || t.noColor || now.Sub(entry.time) < t.config.dimAfter {
+		return line
+	}
+
+	return "[::d]" + strings.TrimSuffix(line, "\n") + "[::D]\n"
+}
+
+// toggleDimLogs enables or disables dimming of old log lines.
+func (t *TUI) toggleDimLogs() {
+	t.dimLogs.Store(!t.dimLogs.Load())
+	t.renderLogView()
+	t.requestRedraw()
+}
+
+// refreshDimmedLogs re-renders the log view if any line became old enough to be dimmed since
+// the view was last rendered. This is skipped while scrolling is paused to not move the view.
+func (t *TUI) refreshDimmedLogs() {
+	if !t.dimLogs.Load() || !t.autoScroll.Load() {
+		return
+	}
+
+	t.logMutex.Lock()
+	lastRender := t.lastLogRender
+	t.logMutex.Unlock()
+
+	now := time.Now()
+
+	for _, entry := range t.logs.Entries() {
+		if now.Sub(entry.time) >= t.config.dimAfter && lastRender.Sub(entry.time) < t.config.dimAfter {
+			t.renderLogView()
+
+			return
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Location of the DMI/SMBIOS information exposed by the kernel.
+const dmiPath = "/sys/class/dmi/id"
+
+// getHardwareInfo returns the system vendor and product name, along with the serial number if
+// enabled. Any field which is empty or can't be read is omitted.
+func getHardwareInfo(showSerial bool) string {
+	fields := []string{}
+
+	for _, name := range []string{"sys_vendor", "product_name", "product_version"} {
+		value := readSysfsString(filepath.Join(dmiPath, name))
+		if value != "" {
+			fields = append(fields, value)
+		}
+	}
+
+	if showSerial {
+		serial := readSysfsString(filepath.Join(dmiPath, "product_serial"))
+		if serial != "" {
+			fields = append(fields, "(serial: "+serial+")")
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
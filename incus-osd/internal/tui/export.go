@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"golang.org/x/sys/unix"
+)
+
+// logExportDir is the local directory where log exports are written.
+const logExportDir = "/var/log/incus-os/"
+
+// exportLogs writes the retained log entries to a timestamped file, also copying it to the
+// first usable removable device, if any. The outcome is reported through a modal.
+func (t *TUI) exportLogs() {
+	modal := t.AddModal("Log export", "log-export")
+	modal.Update("Exporting logs...")
+
+	// Close the modal after a little while.
+	defer modal.DoneAfter(10 * time.Second)
+
+	filename := "incus-osd-" + time.Now().Format("20060102-150405") + ".log"
+
+	err := t.writeLogExport(filepath.Join(logExportDir, filename))
+	if err != nil {
+		modal.Update("[red]Failed to export logs:[white] " + tview.Escape(err.Error()))
+
+		return
+	}
+
+	message := "Logs exported to " + filepath.Join(logExportDir, filename)
+
+	device, err := exportToRemovable(filename, t.writeLogExport)
+	if err != nil {
+		message += "\n\n[red]Failed to copy logs to removable device:[white] " + tview.Escape(err.Error())
+	} else if device != "" {
+		message += "\n\nLogs copied to " + filename + " on " + device
+	}
+
+	modal.Update(message)
+}
+
+// writeLogExport writes all retained log entries, without color tags, to the provided path.
+func (t *TUI) writeLogExport(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	for _, entry := range t.logs.Entries() {
+		_, err := f.WriteString(stripColorTags(entry.text))
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.Close()
+}
+
+// exportToRemovable writes an export through the provided function to the first removable device
+// which is either already mounted or can be mounted. It returns the device used, if any.
+func exportToRemovable(filename string, write func(path string) error) (string, error) {
+	mounts, err := getMounts()
+	if err != nil {
+		return "", err
+	}
+
+	var errs []error
+
+	for _, device := range getRemovablePartitions() {
+		// Use the existing mount point, if the device is already mounted.
+		mountPoint, ok := mounts[device]
+		if ok {
+			err := write(filepath.Join(mountPoint, filename))
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			return device, nil
+		}
+
+		mountDir, err := os.MkdirTemp("", "incus-os-log-export")
+		if err != nil {
+			return "", err
+		}
+
+		err = mountRemovable(device, mountDir)
+		if err != nil {
+			_ = os.Remove(mountDir)
+
+			continue
+		}
+
+		err = write(filepath.Join(mountDir, filename))
+
+		_ = unix.Unmount(mountDir, 0)
+		_ = os.Remove(mountDir)
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		return device, nil
+	}
+
+	return "", errors.Join(errs...)
+}
+
+// getRemovablePartitions returns the device paths of all partitions on removable block devices.
+// Removable devices without a partition table are returned as a whole.
+func getRemovablePartitions() []string {
+	ret := []string{}
+
+	devices, err := filepath.Glob("/sys/block/*")
+	if err != nil {
+		return ret
+	}
+
+	for _, device := range devices {
+		if readSysfsString(filepath.Join(device, "removable")) != "1" {
+			continue
+		}
+
+		name := filepath.Base(device)
+
+		partitions, _ := filepath.Glob(filepath.Join(device, name+"*", "partition"))
+		if len(partitions) == 0 {
+			ret = append(ret, "/dev/"+name)
+
+			continue
+		}
+
+		for _, partition := range partitions {
+			ret = append(ret, "/dev/"+filepath.Base(filepath.Dir(partition)))
+		}
+	}
+
+	return ret
+}
+
+// getMounts returns a map of mounted devices to their mount point.
+func getMounts() (map[string]string, error) {
+	f, err := os.Open("/proc/mounts")
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	ret := map[string]string{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		ret[fields[0]] = fields[1]
+	}
+
+	return ret, scanner.Err()
+}
+
+// mountRemovable attempts to mount the device in read-write mode, using common filesystems for removable media.
+func mountRemovable(device string, mountDir string) error {
+	var err error
+
+	for _, fsType := range []string{"vfat", "exfat", "ext4"} {
+		err = unix.Mount(device, mountDir, fsType, 0, "")
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
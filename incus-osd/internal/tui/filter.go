@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// matchesFilter returns true if the log entry should be displayed given the current filters.
+func (t *TUI) matchesFilter(entry logEntry) bool {
+	if entry.level < t.levelFilter {
+		return false
+	}
+
+	if len(t.sourceFilter) > 0 && !slices.Contains(t.sourceFilter, entry.source) {
+		return false
+	}
+
+	return true
+}
+
+// renderLogView re-populates the log view from the retained log entries, applying any active filters.
+func (t *TUI) renderLogView() {
+	t.logMutex.Lock()
+	defer t.logMutex.Unlock()
+
+	var sb strings.Builder
+
+	t.searchMatches = 0
+	t.lastLogRender = time.Now()
+
+	for _, entry := range t.logs.Entries() {
+		if t.matchesFilter(entry) {
+			sb.WriteString(t.dimLogLine(t.colorize(t.theme.colorizeLogLine(t.highlightMatches(entry.text))), entry, t.lastLogRender))
+		}
+	}
+
+	t.textView.SetText(sb.String())
+}
+
+// getFilterDescription returns a human-readable description of any active log filters.
+func (t *TUI) getFilterDescription() string {
+	t.logMutex.Lock()
+	defer t.logMutex.Unlock()
+
+	filters := []string{}
+
+	if t.levelFilter > slog.LevelInfo {
+		filters = append(filters, t.levelFilter.String()+"+")
+	}
+
+	if len(t.sourceFilter) > 0 {
+		filters = append(filters, "source="+strings.Join(t.sourceFilter, ","))
+	}
+
+	return strings.Join(filters, ", ")
+}
+
+// cycleLevelFilter switches the log view to the next minimum log level (INFO, WARN, ERROR).
+func (t *TUI) cycleLevelFilter() {
+	t.logMutex.Lock()
+
+	switch t.levelFilter {
+	case slog.LevelInfo:
+		t.levelFilter = slog.LevelWarn
+	case slog.LevelWarn:
+		t.levelFilter = slog.LevelError
+	default:
+		t.levelFilter = slog.LevelInfo
+	}
+
+	t.logMutex.Unlock()
+
+	t.renderLogView()
+	t.requestRedraw()
+}
+
+// showSourceFilter displays a dialog allowing the user to select which log sources to display.
+func (t *TUI) showSourceFilter() {
+	sources := t.logs.Sources()
+
+	t.logMutex.Lock()
+	selected := slices.Clone(t.sourceFilter)
+	t.logMutex.Unlock()
+
+	form := tview.NewForm()
+
+	for _, source := range sources {
+		form.AddCheckbox(source, slices.Contains(selected, source), func(checked bool) {
+			selected = slices.DeleteFunc(selected, func(s string) bool { return s == source })
+
+			if checked {
+				selected = append(selected, source)
+			}
+		})
+	}
+
+	form.AddButton("Apply", func() {
+		slices.Sort(selected)
+
+		t.logMutex.Lock()
+		t.sourceFilter = selected
+		t.logMutex.Unlock()
+
+		t.closeDialog()
+		t.renderLogView()
+		t.requestRedraw()
+	})
+
+	form.AddButton("Show all", func() {
+		t.logMutex.Lock()
+		t.sourceFilter = nil
+		t.logMutex.Unlock()
+
+		t.closeDialog()
+		t.renderLogView()
+		t.requestRedraw()
+	})
+
+	form.SetCancelFunc(t.closeDialog)
+	form.SetTitle(" Filter log sources ").SetBorder(true)
+
+	t.showDialog(form, 50, len(sources)*2+5)
+}
+
+// getLogCounts returns the number of warnings and errors logged since the counts were last reset,
+// or an empty string if there were none.
+func (t *TUI) getLogCounts() string {
+	warningSymbol, errorSymbol := "⚠", "✖"
+	if t.serialConsole {
+		warningSymbol, errorSymbol = "W:", "E:"
+	}
+
+	counts := []string{}
+
+	numWarnings := t.warningCount.Load()
+	if numWarnings > 0 {
+		counts = append(counts, fmt.Sprintf("%s%s %d[white]", colorTag(t.theme.warningColor), warningSymbol, numWarnings))
+	}
+
+	numErrors := t.errorCount.Load()
+	if numErrors > 0 {
+		counts = append(counts, fmt.Sprintf("%s%s %d[white]", colorTag(t.theme.errorColor), errorSymbol, numErrors))
+	}
+
+	return strings.Join(counts, "  ")
+}
+
+// resetLogCounts clears the warning and error counts shown in the header.
+func (t *TUI) resetLogCounts() {
+	t.warningCount.Store(0)
+	t.errorCount.Store(0)
+
+	t.requestRedraw()
+}
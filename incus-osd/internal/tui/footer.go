@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/rivo/tview"
+)
+
+// footerSection holds a status line registered by another part of the daemon.
+type footerSection struct {
+	label string
+	text  string
+}
+
+// SetFooterSection displays a labeled status line in the footer until cleared with ClearFooterSection.
+// Setting a key which was already registered replaces its line.
+func (t *TUI) SetFooterSection(key string, label string, text string) {
+	t.footerMutex.Lock()
+	t.footerSections[key] = footerSection{label: tview.Escape(label), text: tview.Escape(text)}
+	t.footerMutex.Unlock()
+
+	t.requestRedraw()
+}
+
+// ClearFooterSection removes the footer status line registered under the given key, if any.
+func (t *TUI) ClearFooterSection(key string) {
+	t.footerMutex.Lock()
+	delete(t.footerSections, key)
+	t.footerMutex.Unlock()
+
+	t.requestRedraw()
+}
+
+// getFooterSections returns the registered footer status lines, ordered by key from the bottom upwards.
+func (t *TUI) getFooterSections() []statusLine {
+	t.footerMutex.Lock()
+	defer t.footerMutex.Unlock()
+
+	ret := make([]statusLine, 0, len(t.footerSections))
+	for _, key := range slices.Sorted(maps.Keys(t.footerSections)) {
+		section := t.footerSections[key]
+		ret = append(ret, statusLine{label: section.label, text: section.text})
+	}
+
+	return ret
+}
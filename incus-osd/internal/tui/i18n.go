@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"os"
+	"strings"
+)
+
+// catalogs holds the translations of the TUI's labels for each supported language, keyed by
+// their English text. Missing entries fall back to English.
+var catalogs = map[string]map[string]string{
+	"fr": {
+		"Connectivity":                    "Connectivité",
+		"Default gateway":                 "Passerelle par défaut",
+		"DNS servers":                     "Serveurs DNS",
+		"Enrollment":                      "Inscription",
+		"Events":                          "Événements",
+		"Hardware":                        "Matériel",
+		"Installed application(s)":        "Application(s) installée(s)",
+		"Logs":                            "Journaux",
+		"Network":                         "Réseau",
+		"Network configuration":           "Configuration réseau",
+		"OS release":                      "Version du système",
+		"Press F4 to toggle the log view": "Appuyez sur F4 pour afficher les journaux",
+		"Press Tab to switch views":       "Appuyez sur Tab pour changer de vue",
+		"Resources":                       "Ressources",
+		"Security":                        "Sécurité",
+		"Storage":                         "Stockage",
+		"Units":                           "Unités",
+		"Uptime":                          "Temps de fonctionnement",
+	},
+}
+
+// getLanguage returns the language to use for the TUI, from INCUSOS_TUI_LANG or the system locale.
+func getLanguage() string {
+	for _, name := range []string{"INCUSOS_TUI_LANG", "LC_ALL", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		// Only keep the language part of a locale such as "fr_FR.UTF-8".
+		language, _, _ := strings.Cut(value, "_")
+		language, _, _ = strings.Cut(language, ".")
+
+		return strings.ToLower(language)
+	}
+
+	return ""
+}
+
+// translate returns the label in the configured language, or as-is if no translation exists.
+func (t *TUI) translate(label string) string {
+	translated, ok := catalogs[t.config.language][label]
+	if !ok {
+		return label
+	}
+
+	return translated
+}
@@ -0,0 +1,290 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// handleInput processes the global keybindings of the TUI.
+func (t *TUI) handleInput(event *tcell.EventKey) *tcell.EventKey {
+	// Let any interactive dialog handle its own input.
+	if t.pages.HasPage("dialog") {
+		return event
+	}
+
+	switch event.Key() { //nolint:exhaustive
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd:
+		// Page through a modal's message if it doesn't fit.
+		if (event.Key() == tcell.KeyPgUp || event.Key() == tcell.KeyPgDn) && t.scrollModal(event.Key()) {
+			return nil
+		}
+
+		if t.logViewVisible() {
+			t.scrollLogView(event.Key())
+
+			return nil
+		}
+	case tcell.KeyTab:
+		if !t.state.ShouldPerformInstall {
+			t.switchView(1)
+		}
+
+		return nil
+	case tcell.KeyBacktab:
+		if !t.state.ShouldPerformInstall {
+			t.switchView(-1)
+		}
+
+		return nil
+	case tcell.KeyRune:
+		if t.activeView.Load() == viewNetwork && event.Rune() == 'e' {
+			t.showNetworkEditor()
+
+			return nil
+		}
+
+		if t.activeView.Load() == viewApplications && event.Rune() == 'a' {
+			t.showApplicationActions()
+
+			return nil
+		}
+
+		if event.Rune() == 'm' {
+			t.showMACs.Store(!t.showMACs.Load())
+			t.requestRedraw()
+
+			return nil
+		}
+
+		if event.Rune() == 's' {
+			t.showSerial.Store(!t.showSerial.Load())
+			t.requestRedraw()
+
+			return nil
+		}
+
+		if !t.logViewVisible() {
+			break
+		}
+
+		switch event.Rune() {
+		case '/':
+			t.showSearch()
+
+			return nil
+		case 'n':
+			t.nextSearchMatch(1)
+
+			return nil
+		case 'N':
+			t.nextSearchMatch(-1)
+
+			return nil
+		case 'G':
+			t.scrollLogView(tcell.KeyEnd)
+
+			return nil
+		case 'd':
+			t.toggleDimLogs()
+
+			return nil
+		default:
+		}
+	case tcell.KeyEscape:
+		t.logMutex.Lock()
+		searching := t.searchQuery != ""
+		t.logMutex.Unlock()
+
+		if searching {
+			t.clearSearch()
+
+			return nil
+		}
+	case tcell.KeyCtrlR:
+		go t.confirmReboot()
+
+		return nil
+	case tcell.KeyCtrlP:
+		go t.confirmPowerOff()
+
+		return nil
+	case tcell.KeyCtrlU:
+		if !t.state.ShouldPerformInstall {
+			go t.confirmUpdateCheck()
+		}
+
+		return nil
+	case tcell.KeyF2:
+		t.cycleLevelFilter()
+
+		return nil
+	case tcell.KeyF3:
+		t.showSourceFilter()
+
+		return nil
+	case tcell.KeyF4:
+		// Toggle between the status page and log view when using the dashboard layout or starting up.
+		if t.config.layout == layoutDashboard || t.startingUp() {
+			t.showLogs.Store(!t.showLogs.Load())
+			t.requestRedraw()
+		}
+
+		return nil
+	case tcell.KeyF6:
+		t.showSecurityEvents()
+
+		return nil
+	case tcell.KeyF7:
+		go t.exportLogs()
+
+		return nil
+	case tcell.KeyF8:
+		t.resetLogCounts()
+
+		return nil
+	case tcell.KeyF9:
+		if !t.state.ShouldPerformInstall {
+			go t.showChannelPrompt()
+		}
+
+		return nil
+	case tcell.KeyF10:
+		go t.exportDiagnostics()
+
+		return nil
+	default:
+	}
+
+	return event
+}
+
+// showDialog displays an interactive dialog on top of everything else and gives it focus.
+// If no width or height is provided, the primitive is expected to handle its own layout.
+func (t *TUI) showDialog(p tview.Primitive, width int, height int) {
+	if width <= 0 || height <= 0 {
+		t.pages.AddPage("dialog", p, true, true)
+	} else {
+		t.pages.AddPage("dialog", centered(p, width, height), true, true)
+	}
+
+	t.app.SetFocus(p)
+}
+
+// openDialog displays an interactive dialog from outside of the TUI goroutine, waiting for it to
+// be shown. It fails if another dialog is already being displayed.
+func (t *TUI) openDialog(p tview.Primitive, width int, height int) error {
+	var err error
+
+	t.app.QueueUpdateDraw(func() {
+		if t.pages.HasPage("dialog") {
+			err = errors.New("another dialog is already being displayed")
+
+			return
+		}
+
+		t.showDialog(p, width, height)
+	})
+
+	return err
+}
+
+// closeDialog removes the current interactive dialog, if any.
+func (t *TUI) closeDialog() {
+	t.pages.RemovePage("dialog")
+}
+
+// centered returns a new primitive which puts the provided primitive in the center and
+// sets its size to the given width and height.
+func centered(p tview.Primitive, width int, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// logViewVisible returns true if the log view is currently being displayed.
+func (t *TUI) logViewVisible() bool {
+	if t.activeView.Load() != viewLogs {
+		return false
+	}
+
+	return (t.config.layout != layoutDashboard && !t.startingUp()) || t.showLogs.Load()
+}
+
+// scrollLogView scrolls the log view according to the provided key. Scrolling up pauses
+// following new log entries, which resumes once scrolled back to the bottom.
+func (t *TUI) scrollLogView(key tcell.Key) {
+	row, _ := t.textView.GetScrollOffset()
+	_, _, _, height := t.textView.GetInnerRect()
+	lastRow := max(t.textView.GetWrappedLineCount()-height, 0)
+
+	// When following new entries, the scroll offset isn't updated until the next draw.
+	if t.autoScroll.Load() {
+		row = lastRow
+	}
+
+	switch key { //nolint:exhaustive
+	case tcell.KeyUp:
+		row--
+	case tcell.KeyDown:
+		row++
+	case tcell.KeyPgUp:
+		row -= height
+	case tcell.KeyPgDn:
+		row += height
+	case tcell.KeyHome:
+		row = 0
+	case tcell.KeyEnd:
+		row = lastRow
+	default:
+	}
+
+	if row >= lastRow {
+		t.setAutoScroll(true)
+		t.textView.ScrollToEnd()
+
+		return
+	}
+
+	t.setAutoScroll(false)
+	t.textView.ScrollTo(max(row, 0), 0)
+}
+
+// setAutoScroll pauses or resumes following new log entries, updating the scroll indicator.
+func (t *TUI) setAutoScroll(enabled bool) {
+	t.logMutex.Lock()
+
+	if enabled || t.autoScroll.Load() {
+		t.pausedLines = 0
+	}
+
+	t.autoScroll.Store(enabled)
+	t.logMutex.Unlock()
+
+	t.updateScrollIndicator()
+}
+
+// updateScrollIndicator asks for the log view's title to be refreshed. The title is only changed
+// when drawing the screen, as this may be called from any goroutine.
+func (t *TUI) updateScrollIndicator() {
+	t.requestRedraw()
+}
+
+// getScrollIndicator returns the log view's title, showing how many entries arrived while paused.
+func (t *TUI) getScrollIndicator() string {
+	t.logMutex.Lock()
+	pausedLines := t.pausedLines
+	t.logMutex.Unlock()
+
+	if t.autoScroll.Load() {
+		return ""
+	}
+
+	return tview.Escape(fmt.Sprintf(" [paused — %d new lines] ", pausedLines))
+}
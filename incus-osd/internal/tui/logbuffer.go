@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
+)
+
+// logEntry holds a single log line along with the information needed to filter it.
+type logEntry struct {
+	level  slog.Level
+	source string
+	text   string
+	time   time.Time
+}
+
+// logBuffer retains the most recent log entries so the log view can be re-rendered when
+// filters change. Once full, the oldest entries are discarded.
+type logBuffer struct {
+	mutex   sync.Mutex
+	entries []logEntry
+	start   int
+	size    int
+}
+
+// newLogBuffer returns a logBuffer retaining at most maxEntries.
+func newLogBuffer(maxEntries int) *logBuffer {
+	return &logBuffer{
+		entries: make([]logEntry, max(maxEntries, 1)),
+	}
+}
+
+// parseLogEntry extracts the level and source from a line produced by CustomTextHandler.
+// Lines are tagged with the application they refer to, or "daemon" otherwise.
+func parseLogEntry(line string) logEntry {
+	ret := logEntry{
+		level:  slog.LevelInfo,
+		source: "daemon",
+		text:   line,
+		time:   time.Now(),
+	}
+
+	// Lines are formatted as "<date> <time> <level> <message> <attributes>".
+	fields := strings.Fields(stripColorTags(line))
+	if len(fields) >= 3 {
+		_ = ret.level.UnmarshalText([]byte(fields[2]))
+	}
+
+	for _, field := range fields {
+		key, value, found := strings.Cut(field, "=")
+		if !found || value == "" {
+			continue
+		}
+
+		// Applications are referenced either through an "application" attribute, or a "name"
+		// attribute which is also used for services and other objects.
+		if key == "application" || (key == "name" && slices.Contains(applications.Supported, value)) {
+			ret.source = value
+		}
+	}
+
+	return ret
+}
+
+// Append adds a new entry to the buffer, discarding the oldest entry if full.
+func (b *logBuffer) Append(entry logEntry) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.size < len(b.entries) {
+		b.entries[(b.start+b.size)%len(b.entries)] = entry
+		b.size++
+
+		return
+	}
+
+	b.entries[b.start] = entry
+	b.start = (b.start + 1) % len(b.entries)
+}
+
+// Entries returns a copy of all entries currently in the buffer, oldest first.
+func (b *logBuffer) Entries() []logEntry {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ret := make([]logEntry, 0, b.size)
+	for i := range b.size {
+		ret = append(ret, b.entries[(b.start+i)%len(b.entries)])
+	}
+
+	return ret
+}
+
+// Sources returns a sorted list of all distinct sources seen in the buffer.
+func (b *logBuffer) Sources() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ret := []string{}
+
+	for _, entry := range b.entries[:b.size] {
+		if !slices.Contains(ret, entry.source) {
+			ret = append(ret, entry.source)
+		}
+	}
+
+	slices.Sort(ret)
+
+	return ret
+}
+
+// logColorTags lists all color tags which may be added to log lines.
+var logColorTags = []string{"[green]", "[yellow]", "[purple]", "[red]", "[white]"}
+
+// stripColorTags removes any color tags added by CustomTextHandler.
+func stripColorTags(s string) string {
+	for _, tag := range logColorTags {
+		s = strings.ReplaceAll(s, tag, "")
+	}
+
+	return s
+}
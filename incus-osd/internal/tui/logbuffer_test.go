@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+func TestLogBufferLimit(t *testing.T) {
+	t.Parallel()
+
+	tuiApp := newLogTestTUI(5000)
+
+	for i := range 10000 {
+		_, err := fmt.Fprintf(tuiApp, "2026-01-01 00:00:00 [green]INFO[white] Line %d[purple] key=value[white]\n", i)
+		require.NoError(t, err)
+	}
+
+	// Only the most recent lines should be retained, each one intact.
+	entries := tuiApp.logs.Entries()
+	require.Len(t, entries, 5000)
+	require.Equal(t, "2026-01-01 00:00:00 [green]INFO[white] Line 5000[purple] key=value[white]\n", entries[0].text)
+	require.Equal(t, "2026-01-01 00:00:00 [green]INFO[white] Line 9999[purple] key=value[white]\n", entries[4999].text)
+}
+
+func TestLogCounts(t *testing.T) {
+	t.Parallel()
+
+	tuiApp := newLogTestTUI(100)
+	tuiApp.theme = getTheme("default")
+
+	require.Empty(t, tuiApp.getLogCounts())
+
+	for _, level := range []string{"[yellow]WARN", "[red]ERROR", "[red]ERROR", "[green]INFO"} {
+		_, err := fmt.Fprintf(tuiApp, "2026-01-01 00:00:00 %s[white] Test message\n", level)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "[yellow]⚠ 1[white]  [red]✖ 2[white]", tuiApp.getLogCounts())
+
+	tuiApp.warningCount.Store(0)
+	require.Equal(t, "[red]✖ 2[white]", tuiApp.getLogCounts())
+}
+
+func TestParseLogEntrySource(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "daemon", parseLogEntry("2026-01-01 00:00:00 [green]INFO[white] Starting service[purple] name=ceph[white]\n").source)
+	require.Equal(t, "incus", parseLogEntry("2026-01-01 00:00:00 [green]INFO[white] Starting application[purple] name=incus version=202601010000[white]\n").source)
+	require.Equal(t, "migration-manager", parseLogEntry("2026-01-01 00:00:00 [green]INFO[white] Downloading application update[purple] application=migration-manager channel=stable[white]\n").source)
+}
+
+func TestMergeKernelMessages(t *testing.T) {
+	t.Parallel()
+
+	at := func(s string) time.Time {
+		ts, err := time.ParseInLocation(time.DateTime, s, time.Local)
+		require.NoError(t, err)
+
+		return ts
+	}
+
+	entries := []logEntry{
+		parseLogEntry("2026-01-01 00:00:00 [green]INFO[white] First\n"),
+		parseLogEntry("Started incus-osd.service.\n"),
+		parseLogEntry("2026-01-01 00:00:10 [green]INFO[white] Second\n"),
+	}
+
+	merged := mergeKernelMessages(entries, []systemd.KernelMessage{
+		{Time: at("2026-01-01 00:00:05"), Priority: 4, Message: "[Firmware Bug]: TSC doesn't count"},
+	})
+
+	require.Len(t, merged, 4)
+	require.Equal(t, "2026-01-01 00:00:00 [green]INFO[white] First\n", merged[0].text)
+	require.Equal(t, "Started incus-osd.service.\n", merged[1].text)
+	require.Equal(t, "kernel", merged[2].source)
+	require.Equal(t, slog.LevelWarn, merged[2].level)
+	require.Equal(t, "2026-01-01 00:00:05 [yellow]WARN[white] [Firmware Bug[]: TSC doesn't count\n", merged[2].text)
+	require.Equal(t, "2026-01-01 00:00:10 [green]INFO[white] Second\n", merged[3].text)
+}
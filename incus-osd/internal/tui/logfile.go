@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// How long to wait before trying to write to the log file again after a failure.
+var logFileRetryInterval = time.Minute
+
+const (
+	// logFileMaxSize is the size at which the log file gets rotated.
+	logFileMaxSize = 10 * 1024 * 1024
+
+	// logFileGenerations is the number of rotated log files to retain.
+	logFileGenerations = 3
+)
+
+// logFile mirrors log lines to a file on disk, rotating it once it grows past maxSize. If
+// writing fails (disk full, read-only filesystem, etc), lines are dropped from the file until
+// a retry succeeds.
+type logFile struct {
+	mutex sync.Mutex
+
+	path        string
+	file        io.WriteCloser
+	openFunc    func(path string) (io.WriteCloser, error)
+	failed      bool
+	lastFailure time.Time
+
+	size        int64
+	maxSize     int64
+	generations int
+}
+
+// newLogFile returns a logFile for the given path. The file itself is opened on first write.
+func newLogFile(path string) *logFile {
+	return &logFile{
+		path:        path,
+		maxSize:     logFileMaxSize,
+		generations: logFileGenerations,
+		openFunc: func(path string) (io.WriteCloser, error) {
+			err := os.MkdirAll(filepath.Dir(path), 0o755)
+			if err != nil {
+				return nil, err
+			}
+
+			return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		},
+	}
+}
+
+// Write appends the provided bytes to the log file. An error is only returned when the
+// log file transitions from a working to a failed state, allowing the caller to surface
+// a single warning rather than one per log line.
+func (l *logFile) Write(p []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Don't hammer a broken filesystem, only retry periodically.
+	if l.failed && time.Since(l.lastFailure) < logFileRetryInterval {
+		return nil
+	}
+
+	// Rotate before the new lines would push the file past its maximum size.
+	if l.maxSize > 0 && l.file != nil && l.size > 0 && l.size+int64(len(p)) > l.maxSize {
+		err := l.rotate()
+		if err != nil {
+			return l.markFailed(err)
+		}
+	}
+
+	if l.file == nil {
+		f, err := l.openFunc(l.path)
+		if err != nil {
+			return l.markFailed(err)
+		}
+
+		l.file = f
+		l.size = 0
+
+		// Account for any existing content when appending to a file from a previous boot.
+		st, ok := f.(interface{ Stat() (os.FileInfo, error) })
+		if ok {
+			info, err := st.Stat()
+			if err == nil {
+				l.size = info.Size()
+			}
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+
+	if err != nil {
+		_ = l.file.Close()
+		l.file = nil
+
+		return l.markFailed(err)
+	}
+
+	l.failed = false
+
+	return nil
+}
+
+// rotate closes the current log file and shifts it and any older generations by one,
+// discarding the oldest. The next write opens a fresh file.
+func (l *logFile) rotate() error {
+	err := l.file.Close()
+	l.file = nil
+
+	if err != nil {
+		return err
+	}
+
+	for i := l.generations - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if l.generations > 0 {
+		return os.Rename(l.path, l.path+".1")
+	}
+
+	return os.Remove(l.path)
+}
+
+// Close closes the underlying log file, if open.
+func (l *logFile) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+func (l *logFile) markFailed(err error) error {
+	wasFailed := l.failed
+
+	l.failed = true
+	l.lastFailure = time.Now()
+
+	if wasFailed {
+		return nil
+	}
+
+	return err
+}
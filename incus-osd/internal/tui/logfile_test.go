@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct {
+	fail   bool
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, errors.New("no space left on device")
+	}
+
+	w.writes++
+
+	return len(p), nil
+}
+
+func (*failingWriter) Close() error {
+	return nil
+}
+
+func TestLogFileFailure(t *testing.T) {
+	t.Parallel()
+
+	w := &failingWriter{fail: true}
+
+	tuiApp := newLogTestTUI(100)
+	tuiApp.logFile = &logFile{
+		openFunc: func(_ string) (io.WriteCloser, error) {
+			return w, nil
+		},
+	}
+
+	// Logging must keep working while the log file is failing, with a single warning.
+	for range 10 {
+		_, err := tuiApp.Write([]byte("2026-01-01 00:00:00 [green]INFO[white] Test message\n"))
+		require.NoError(t, err)
+	}
+
+	entries := tuiApp.logs.Entries()
+	require.Len(t, entries, 11)
+	require.Equal(t, 1, strings.Count(tuiApp.textView.GetText(true), "Unable to write to log file"))
+
+	// Once the filesystem recovers, writes should resume after the retry interval.
+	w.fail = false
+	tuiApp.logFile.lastFailure = time.Now().Add(-2 * logFileRetryInterval)
+
+	_, err := tuiApp.Write([]byte("2026-01-01 00:00:00 [green]INFO[white] Test message\n"))
+	require.NoError(t, err)
+	require.Equal(t, 1, w.writes)
+	require.False(t, tuiApp.logFile.failed)
+}
+
+func TestLogFileRotation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "incus-osd.log")
+	line := []byte(strings.Repeat("x", 29) + "\n")
+
+	l := newLogFile(path)
+	l.maxSize = 64
+	l.generations = 2
+
+	for range 7 {
+		require.NoError(t, l.Write(line))
+	}
+
+	require.NoError(t, l.Close())
+
+	// Each rotated file holds two full lines, with the oldest generation discarded.
+	for name, size := range map[string]int{path: 30, path + ".1": 60, path + ".2": 60} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		require.Equal(t, int64(size), info.Size())
+	}
+
+	require.NoFileExists(t, path+".3")
+}
@@ -35,6 +35,41 @@ func (*CustomTextHandler) Enabled(_ context.Context, level slog.Level) bool {
 	return true
 }
 
+// getLevelColor returns the color tag used for the level token. Debug messages are never shown,
+// so don't need a color.
+func getLevelColor(level slog.Level) string {
+	switch level {
+	case slog.LevelInfo:
+		return "[green]"
+	case slog.LevelWarn:
+		return "[yellow]"
+	case slog.LevelError:
+		return "[red]"
+	default:
+	}
+
+	return ""
+}
+
+// colorizePlainLogLine adds color tags to a log line previously written without them, such as
+// one read back from the journal. Lines in an unexpected format are returned unmodified.
+func colorizePlainLogLine(line string) string {
+	// Lines are formatted as "<date> <time> <level> <message> <attributes>".
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) < 4 {
+		return line
+	}
+
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(fields[2]))
+	if err != nil {
+		return line
+	}
+
+	return fields[0] + " " + fields[1] + " " + getLevelColor(level) + fields[2] + "[white] " + fields[3]
+}
+
 // Handle handles the Record.
 func (cth *CustomTextHandler) Handle(_ context.Context, r slog.Record) error {
 	var buf strings.Builder
@@ -47,21 +82,7 @@ func (cth *CustomTextHandler) Handle(_ context.Context, r slog.Record) error {
 		return err
 	}
 
-	levelColor := ""
-
-	switch r.Level {
-	case slog.LevelDebug:
-		levelColor = "[blue]"
-	case slog.LevelInfo:
-		levelColor = "[green]"
-	case slog.LevelWarn:
-		levelColor = "[yellow]"
-	case slog.LevelError:
-		levelColor = "[red]"
-	default:
-	}
-
-	_, err = buf.WriteString(levelColor + r.Level.String() + "[white] ")
+	_, err = buf.WriteString(getLevelColor(r.Level) + r.Level.String() + "[white] ")
 	if err != nil {
 		return err
 	}
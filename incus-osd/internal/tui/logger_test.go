@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomTextHandlerColors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    slog.Level
+		expected string
+	}{
+		{slog.LevelInfo, "2026-01-01 00:00:00 [green]INFO[white] Test message[purple] key=value[white]\n"},
+		{slog.LevelWarn, "2026-01-01 00:00:00 [yellow]WARN[white] Test message[purple] key=value[white]\n"},
+		{slog.LevelError, "2026-01-01 00:00:00 [red]ERROR[white] Test message[purple] key=value[white]\n"},
+	}
+
+	for _, tc := range tests {
+		var buf bytes.Buffer
+
+		r := slog.NewRecord(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), tc.level, "Test message", 0)
+		r.AddAttrs(slog.String("key", "value"))
+
+		err := NewCustomTextHandler(&buf).Handle(context.Background(), r)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, buf.String())
+
+		// The plain text copy must not contain any color tags.
+		require.Equal(t, "2026-01-01 00:00:00 "+tc.level.String()+" Test message key=value\n", stripColorTags(buf.String()))
+	}
+}
+
+func TestColorizePlainLogLine(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "2025-01-01 12:00:00 [yellow]WARN[white] Something happened err=foo", colorizePlainLogLine("2025-01-01 12:00:00 WARN Something happened err=foo"))
+	require.Equal(t, "Started incus-osd.service.", colorizePlainLogLine("Started incus-osd.service."))
+}
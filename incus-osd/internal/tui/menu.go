@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"errors"
+
+	"github.com/rivo/tview"
+)
+
+// DisplayMenu asks the user to pick one of the provided items, blocking until a choice has been made.
+// It returns the index of the selected item, or -1 if the menu was cancelled with Escape. This must
+// not be called from a TUI event handler.
+func (t *TUI) DisplayMenu(title string, items []string) (int, error) {
+	if len(items) == 0 {
+		return -1, errors.New("at least one menu item must be provided")
+	}
+
+	result := make(chan int, 1)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetTitle(" " + title + " ").SetBorder(true)
+
+	// Size the menu to fit its title and longest item, within the borders.
+	width := tview.TaggedStringWidth(title) + 4
+	for _, item := range items {
+		list.AddItem(item, "", 0, nil)
+		width = max(width, tview.TaggedStringWidth(item)+4)
+	}
+
+	list.SetSelectedFunc(func(index int, _ string, _ string, _ rune) {
+		t.closeDialog()
+		result <- index
+	})
+
+	list.SetDoneFunc(func() {
+		t.closeDialog()
+		result <- -1
+	})
+
+	err := t.openDialog(list, width, len(items)+2)
+	if err != nil {
+		return -1, err
+	}
+
+	return <-result, nil
+}
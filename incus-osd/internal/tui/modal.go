@@ -1,16 +1,110 @@
 package tui
 
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lxc/incus/v7/shared/units"
+	"github.com/rivo/tview"
+)
+
 // Modal holds the information for a given modal dialog.
 type Modal struct {
 	title    string
 	category string
 	message  string
 	progress float64
+	details  string
 	isDone   bool
 
+	busy         bool
+	spinnerFrame int
+
+	scrollOffset int
+	scrollMax    int
+	scrollPage   int
+
+	transfers []transferSample
+	bars      []*modalProgressBar
+
+	dismissTimer *time.Timer
+
 	t *TUI
 }
 
+// modalProgressBar holds the state of one of several labeled progress bars shown in a modal.
+type modalProgressBar struct {
+	key      string
+	label    string
+	progress float64
+	done     bool
+}
+
+// transferSample records how many bytes had been transferred at a given time.
+type transferSample struct {
+	time  time.Time
+	bytes int64
+}
+
+// animateSpinner advances the modal's spinner until the modal is done.
+func (m *Modal) animateSpinner() {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		m.t.modalMutex.Lock()
+		done := m.isDone
+		m.spinnerFrame++
+		m.t.modalMutex.Unlock()
+
+		if done {
+			return
+		}
+
+		m.t.quickDraw()
+	}
+}
+
+// scrollModal scrolls the text of the displayed modal by a page, returning false if it has nothing to scroll.
+func (t *TUI) scrollModal(key tcell.Key) bool {
+	t.modalMutex.Lock()
+	defer t.modalMutex.Unlock()
+
+	m := t.currentModal
+	if m == nil || m.scrollMax == 0 {
+		return false
+	}
+
+	if key == tcell.KeyPgUp {
+		m.scrollOffset = max(m.scrollOffset-m.scrollPage, 0)
+	} else {
+		m.scrollOffset = min(m.scrollOffset+m.scrollPage, m.scrollMax)
+	}
+
+	if !m.isDone {
+		t.renderModal(t.currentModalTitle, m)
+	}
+
+	return true
+}
+
+// countWrappedLines returns the number of lines the text takes when word wrapped to the given width.
+func countWrappedLines(text string, width int) int {
+	count := 0
+
+	for line := range strings.SplitSeq(text, "\n") {
+		count += max(len(tview.WordWrap(line, max(width, 1))), 1)
+	}
+
+	return count
+}
+
+// Number of recent samples used to estimate the transfer rate.
+const maxTransferSamples = 10
+
 // Update sets the current message of the modal dialog.
 func (m *Modal) Update(message string) {
 	m.message = message
@@ -20,15 +114,155 @@ func (m *Modal) Update(message string) {
 
 // UpdateProgress sets the current modal's progress, expressed as a float between 0 and 1.
 func (m *Modal) UpdateProgress(progress float64) {
+	m.t.modalMutex.Lock()
+
 	m.progress = progress
+	m.details = ""
+	m.transfers = nil
+
+	m.t.modalMutex.Unlock()
 
 	m.t.quickDraw()
 }
 
+// UpdateTransferProgress sets the current modal's progress based on the number of bytes transferred,
+// also displaying the transfer rate and estimated time remaining. A total of zero or less indicates
+// that the size of the transfer is unknown.
+func (m *Modal) UpdateTransferProgress(current int64, total int64) {
+	m.t.modalMutex.Lock()
+
+	// Start over when a new transfer begins.
+	if len(m.transfers) > 0 && current < m.transfers[len(m.transfers)-1].bytes {
+		m.transfers = nil
+	}
+
+	m.transfers = append(m.transfers, transferSample{time: time.Now(), bytes: current})
+	if len(m.transfers) > maxTransferSamples {
+		m.transfers = m.transfers[len(m.transfers)-maxTransferSamples:]
+	}
+
+	m.progress = 0
+	if total > 0 {
+		m.progress = math.Min(float64(current)/float64(total), 1)
+	}
+
+	m.details = getTransferDetails(m.transfers, total)
+
+	m.t.modalMutex.Unlock()
+
+	m.t.quickDraw()
+}
+
+// getTransferDetails returns a summary of the transfer progress, such as "45% · 12.3MiB/s · ETA 0:42".
+func getTransferDetails(transfers []transferSample, total int64) string {
+	current := transfers[len(transfers)-1].bytes
+	details := []string{}
+
+	if total > 0 {
+		details = append(details, fmt.Sprintf("%d%%", min(current*100/total, 100)))
+	}
+
+	elapsed := transfers[len(transfers)-1].time.Sub(transfers[0].time).Seconds()
+	if elapsed <= 0 {
+		return strings.Join(details, " · ")
+	}
+
+	rate := float64(current-transfers[0].bytes) / elapsed
+	details = append(details, units.GetByteSizeStringIEC(int64(rate), 1)+"/s")
+
+	if total > current && rate > 0 {
+		eta := time.Duration(float64(total-current)/rate) * time.Second
+		details = append(details, "ETA "+formatETA(eta))
+	}
+
+	return strings.Join(details, " · ")
+}
+
+// formatETA formats a duration as "m:ss", or "h:mm:ss" for longer durations.
+func formatETA(d time.Duration) string {
+	seconds := int(d.Seconds())
+
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// SetProgressBar adds or updates a labeled progress bar identified by key, with progress expressed as
+// a float between 0 and 1. Multiple progress bars are displayed stacked, in the order they were added.
+func (m *Modal) SetProgressBar(key string, label string, progress float64) {
+	m.t.modalMutex.Lock()
+
+	bar := m.getProgressBar(key)
+	if bar == nil {
+		bar = &modalProgressBar{key: key}
+		m.bars = append(m.bars, bar)
+	}
+
+	bar.label = label
+	bar.progress = progress
+
+	m.t.modalMutex.Unlock()
+
+	m.t.quickDraw()
+}
+
+// CompleteProgressBar marks the progress bar identified by key as completed.
+func (m *Modal) CompleteProgressBar(key string) {
+	m.t.modalMutex.Lock()
+
+	bar := m.getProgressBar(key)
+	if bar != nil {
+		bar.progress = 1
+		bar.done = true
+	}
+
+	m.t.modalMutex.Unlock()
+
+	m.t.quickDraw()
+}
+
+func (m *Modal) getProgressBar(key string) *modalProgressBar {
+	for _, bar := range m.bars {
+		if bar.key == key {
+			return bar
+		}
+	}
+
+	return nil
+}
+
 // Done indicates that the modal is no longer needed and should be removed.
 func (m *Modal) Done() {
+	m.t.modalMutex.Lock()
+
+	if m.dismissTimer != nil {
+		m.dismissTimer.Stop()
+		m.dismissTimer = nil
+	}
+
 	m.isDone = true
 	m.category = "__DONE__"
 
+	m.t.modalMutex.Unlock()
+
 	m.t.quickDraw()
 }
+
+// DoneAfter removes the modal once the given duration has elapsed, replacing any previously
+// scheduled removal. Calling Done first cancels the removal.
+func (m *Modal) DoneAfter(d time.Duration) {
+	m.t.modalMutex.Lock()
+	defer m.t.modalMutex.Unlock()
+
+	if m.isDone {
+		return
+	}
+
+	if m.dismissTimer != nil {
+		m.dismissTimer.Stop()
+	}
+
+	m.dismissTimer = time.AfterFunc(d, m.Done)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransferDetails(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	transfers := []transferSample{
+		{time: now, bytes: 0},
+		{time: now.Add(10 * time.Second), bytes: 100 * 1024 * 1024},
+	}
+
+	require.Equal(t, "45% · 10.0MiB/s · ETA 0:12", getTransferDetails(transfers, 220*1024*1024))
+
+	// The ETA can't be computed without knowing the total size.
+	require.Equal(t, "10.0MiB/s", getTransferDetails(transfers, 0))
+
+	// No rate yet with a single sample.
+	require.Equal(t, "0%", getTransferDetails(transfers[:1], 220*1024*1024))
+
+	require.Equal(t, "1:01:01", formatETA(3661*time.Second))
+}
+
+func TestSpinner(t *testing.T) {
+	t.Parallel()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	require.NoError(t, screen.Init())
+	screen.SetSize(3, 1)
+
+	spinner := NewSpinner()
+	spinner.SetFrames(spinnerFramesASCII)
+	spinner.SetRect(0, 0, 3, 1)
+
+	// Frames wrap around once the end of the animation is reached.
+	spinner.SetFrame(6)
+	spinner.Draw(screen)
+
+	r, _, _ := screen.Get(1, 0)
+	require.Equal(t, "-", r)
+}
+
+func TestProgressBarPercent(t *testing.T) {
+	t.Parallel()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	require.NoError(t, screen.Init())
+	screen.SetSize(10, 1)
+
+	progressBar := NewProgressBar()
+	progressBar.SetRect(0, 0, 10, 1)
+	progressBar.SetProgress(45)
+	progressBar.SetShowPercent(true)
+	progressBar.Draw(screen)
+
+	text := ""
+
+	for i := 3; i < 6; i++ {
+		r, _, _ := screen.Get(i, 0)
+		text += r
+	}
+
+	require.Equal(t, "45%", text)
+
+	// The digits within the filled area are inverted.
+	_, style, _ := screen.Get(3, 0)
+	_, bg, _ := style.Decompose()
+	require.Equal(t, tview.Styles.PrimaryTextColor, bg)
+}
+
+func TestCountWrappedLines(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 1, countWrappedLines("short", 20))
+	require.Equal(t, 4, countWrappedLines("one two three four five six seven\n\nend", 20))
+}
+
+func TestModalDoneAfter(t *testing.T) {
+	t.Parallel()
+
+	tui, _ := newTestTUI(t, nil)
+
+	modal := tui.AddModal("Test", "test")
+	modal.DoneAfter(10 * time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return tui.GetModal("test") == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// Removing the modal first cancels its scheduled removal.
+	modal = tui.AddModal("Test", "test")
+	modal.DoneAfter(time.Hour)
+	modal.Done()
+
+	require.Nil(t, modal.dismissTimer)
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+	"github.com/lxc/incus-os/incus-osd/internal/providers"
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// networkEdit holds the values entered in the network configuration editor.
+type networkEdit struct {
+	iface     string
+	addresses string
+	gateway   string
+	dns       string
+}
+
+// showNetworkEditor displays a form to change the addresses, gateway and DNS servers of one of
+// the configured network interfaces.
+func (t *TUI) showNetworkEditor() {
+	cfg := t.state.System.Network.Config
+	if cfg == nil || len(cfg.Interfaces) == 0 {
+		t.showMessage("Network configuration", "No network interfaces are currently configured.")
+
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Interfaces))
+	for _, iface := range cfg.Interfaces {
+		names = append(names, iface.Name)
+	}
+
+	edit := networkEdit{}
+
+	form := tview.NewForm()
+
+	addressField := tview.NewInputField().SetLabel("Addresses").SetFieldWidth(40).SetChangedFunc(func(text string) {
+		edit.addresses = text
+	})
+
+	gatewayField := tview.NewInputField().SetLabel("Gateway").SetFieldWidth(40).SetChangedFunc(func(text string) {
+		edit.gateway = text
+	})
+
+	dnsField := tview.NewInputField().SetLabel("DNS servers").SetFieldWidth(40).SetChangedFunc(func(text string) {
+		edit.dns = text
+	})
+
+	// Populate the fields with the current configuration of the selected interface.
+	form.AddDropDown("Interface", names, 0, func(name string, _ int) {
+		edit.iface = name
+
+		iface := cfg.Interfaces[slices.IndexFunc(cfg.Interfaces, func(i api.SystemNetworkInterface) bool {
+			return i.Name == name
+		})]
+
+		gateways := []string{}
+
+		for _, route := range iface.Routes {
+			if route.To == "0.0.0.0/0" || route.To == "::/0" {
+				gateways = append(gateways, route.Via)
+			}
+		}
+
+		addressField.SetText(strings.Join(iface.Addresses, ", "))
+		gatewayField.SetText(strings.Join(gateways, ", "))
+	})
+
+	nameservers := []string{}
+	if cfg.DNS != nil {
+		nameservers = cfg.DNS.Nameservers
+	}
+
+	dnsField.SetText(strings.Join(nameservers, ", "))
+
+	form.AddFormItem(addressField)
+	form.AddFormItem(gatewayField)
+	form.AddFormItem(dnsField)
+
+	// Report invalid values on top of the editor, returning to it once acknowledged.
+	showError := func(msg string) {
+		modal := tview.NewModal().
+			SetText(tview.Escape(msg)).
+			AddButtons([]string{"Close"}).
+			SetDoneFunc(func(_ int, _ string) {
+				t.pages.RemovePage("network-error")
+				t.app.SetFocus(form)
+			})
+		modal.SetTitle(" Invalid network configuration ")
+
+		t.pages.AddPage("network-error", modal, true, true)
+		t.app.SetFocus(modal)
+	}
+
+	form.AddButton("Apply", func() {
+		newCfg, err := buildNetworkConfig(cfg, edit)
+		if err != nil {
+			showError(err.Error())
+
+			return
+		}
+
+		t.closeDialog()
+
+		go t.applyNetworkConfig(newCfg)
+	})
+
+	form.AddButton("Cancel", t.closeDialog)
+	form.SetCancelFunc(t.closeDialog)
+	form.SetTitle(" Network configuration ").SetBorder(true)
+
+	t.showDialog(form, 60, 13)
+}
+
+// buildNetworkConfig validates the edited values, returning a copy of the current configuration
+// with them applied to the selected interface.
+func buildNetworkConfig(cfg *api.SystemNetworkConfig, edit networkEdit) (*api.SystemNetworkConfig, error) {
+	addresses := splitList(edit.addresses)
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one address must be provided")
+	}
+
+	prefixes := []netip.Prefix{}
+
+	for _, address := range addresses {
+		// Dynamic addressing keywords are passed through as-is.
+		if slices.Contains([]string{"dhcp4", "dhcp6", "slaac"}, address) {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address '%s', expected an address in CIDR notation, dhcp4, dhcp6 or slaac", address)
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	routes := []api.SystemNetworkRoute{}
+
+	for _, gateway := range splitList(edit.gateway) {
+		addr, err := netip.ParseAddr(gateway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway '%s'", gateway)
+		}
+
+		// The gateway must be reachable through one of the static addresses.
+		if !slices.ContainsFunc(prefixes, func(prefix netip.Prefix) bool {
+			return prefix.Masked().Contains(addr)
+		}) {
+			return nil, fmt.Errorf("gateway '%s' isn't part of any of the configured subnets", gateway)
+		}
+
+		route := api.SystemNetworkRoute{To: "0.0.0.0/0", Via: gateway}
+		if addr.Is6() {
+			route.To = "::/0"
+		}
+
+		routes = append(routes, route)
+	}
+
+	nameservers := splitList(edit.dns)
+	for _, nameserver := range nameservers {
+		_, err := netip.ParseAddr(nameserver)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNS server '%s'", nameserver)
+		}
+	}
+
+	// Work on a copy, so the current configuration is left untouched if applying fails.
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	newCfg := &api.SystemNetworkConfig{}
+
+	err = json.Unmarshal(body, newCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(newCfg.Interfaces, func(i api.SystemNetworkInterface) bool {
+		return i.Name == edit.iface
+	})
+	if index < 0 {
+		return nil, fmt.Errorf("unknown interface '%s'", edit.iface)
+	}
+
+	iface := &newCfg.Interfaces[index]
+	iface.Addresses = addresses
+
+	// Replace any existing default routes, keeping other static routes.
+	iface.Routes = slices.DeleteFunc(iface.Routes, func(route api.SystemNetworkRoute) bool {
+		return route.To == "0.0.0.0/0" || route.To == "::/0"
+	})
+	iface.Routes = append(iface.Routes, routes...)
+
+	if newCfg.DNS == nil {
+		newCfg.DNS = &api.SystemNetworkDNS{}
+	}
+
+	newCfg.DNS.Nameservers = nameservers
+
+	return newCfg, nil
+}
+
+// applyNetworkConfig applies the provided network configuration, reporting progress through a modal.
+func (t *TUI) applyNetworkConfig(cfg *api.SystemNetworkConfig) {
+	modal := t.AddModal("Network configuration", "network-config")
+	modal.Update("Applying new network configuration...")
+
+	slog.Info("Applying new network configuration from the console")
+
+	err := systemd.ApplyAndSaveNetworkConfiguration(context.Background(), t.state, cfg, 30*time.Second, providers.Notify)
+
+	modal.Done()
+
+	if err != nil {
+		slog.Error("Failed to update network configuration: " + err.Error())
+		t.showMessage("Network configuration", "[red]Failed to apply the network configuration:[white] "+tview.Escape(err.Error()))
+
+		return
+	}
+
+	t.requestRedraw()
+}
+
+// showMessage displays a simple message dialog, closed with its single button.
+func (t *TUI) showMessage(title string, msg string) {
+	modal := tview.NewModal().
+		SetText(t.colorize(msg)).
+		AddButtons([]string{"Close"}).
+		SetDoneFunc(func(_ int, _ string) {
+			t.closeDialog()
+		})
+	modal.SetTitle(" " + title + " ")
+
+	t.queueUpdate(func() {
+		t.showDialog(modal, 0, 0)
+	})
+}
+
+// splitList splits a comma or whitespace separated list, ignoring empty entries.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// Views which can be switched between using Tab and Shift-Tab.
+const (
+	viewLogs = iota
+	viewNetwork
+	viewApplications
+	viewUnits
+	viewStorage
+	viewEvents
+)
+
+var viewNames = []string{"Logs", "Network", "Applications", "Units", "Storage", "Events"}
+
+// keyUnits lists the systemd units always shown in the units view, along with any failed units.
+var keyUnits = []string{
+	"incus-osd.service",
+	"systemd-networkd.service",
+	"systemd-resolved.service",
+	"systemd-timesyncd.service",
+}
+
+// switchView cycles through the available views, in the given direction.
+func (t *TUI) switchView(offset int) {
+	numViews := int64(len(viewNames))
+	t.activeView.Store((t.activeView.Load() + int64(offset) + numViews) % numViews)
+
+	t.requestRedraw()
+}
+
+// getTabBar returns the tab bar shown in the header, highlighting the active view.
+func (t *TUI) getTabBar() string {
+	tabs := make([]string, 0, len(viewNames))
+
+	for i, name := range viewNames {
+		name = t.translate(name)
+
+		if int64(i) == t.activeView.Load() {
+			tabs = append(tabs, "[black:white] "+name+" [-:-]")
+		} else {
+			tabs = append(tabs, " "+name+" ")
+		}
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+// renderNetworkView returns the content of the network view.
+func (t *TUI) renderNetworkView() string {
+	var sb strings.Builder
+
+	if len(t.state.System.Network.State.Interfaces) == 0 {
+		sb.WriteString("No network interfaces are currently configured.\n")
+	}
+
+	names := make([]string, 0, len(t.state.System.Network.State.Interfaces))
+	for name := range t.state.System.Network.State.Interfaces {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		iface := t.state.System.Network.State.Interfaces[name]
+
+		fmt.Fprintf(&sb, "%s%s[white] (%s, %s)\n", colorTag(t.theme.labelColor), name, iface.Type, iface.State)
+
+		if iface.Hwaddr != "" {
+			fmt.Fprintf(&sb, "  MAC address: %s\n", iface.Hwaddr)
+		}
+
+		carrier, known := getCarrier(name, iface.Hwaddr)
+		speed := formatLinkSpeed(iface.Speed)
+
+		switch {
+		case known && !carrier:
+			sb.WriteString("  Link: " + colorTag(t.theme.errorColor) + "no carrier[white]\n")
+		case speed != "":
+			fmt.Fprintf(&sb, "  Link: up, %s\n", speed)
+		case known:
+			sb.WriteString("  Link: up\n")
+		default:
+		}
+
+		if len(iface.Addresses) > 0 {
+			fmt.Fprintf(&sb, "  Addresses: %s\n", strings.Join(iface.Addresses, ", "))
+		}
+
+		for _, route := range iface.Routes {
+			if route.To == "default" || route.To == "0.0.0.0/0" || route.To == "::/0" {
+				fmt.Fprintf(&sb, "  Gateway: %s\n", route.Via)
+			}
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if t.state.System.Network.Config != nil && t.state.System.Network.Config.DNS != nil {
+		dns := t.state.System.Network.Config.DNS
+
+		if len(dns.Nameservers) > 0 {
+			fmt.Fprintf(&sb, "%sDNS servers:[white] %s\n", colorTag(t.theme.labelColor), strings.Join(dns.Nameservers, ", "))
+		}
+
+		if len(dns.SearchDomains) > 0 {
+			fmt.Fprintf(&sb, "%sDNS search domains:[white] %s\n", colorTag(t.theme.labelColor), strings.Join(dns.SearchDomains, ", "))
+		}
+	}
+
+	sb.WriteString("\nPress 'e' to edit the network configuration.\n")
+
+	return sb.String()
+}
+
+// renderApplicationsView returns the content of the applications view.
+func (t *TUI) renderApplicationsView() (string, error) {
+	apps, err := applications.GetInstalled(context.Background(), t.state)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	if len(apps) == 0 {
+		sb.WriteString("No applications are currently installed.\n")
+	}
+
+	slices.SortFunc(apps, func(a applications.Application, b applications.Application) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	// Applications providing a service use a unit matching their name.
+	patterns := make([]string, 0, len(apps))
+	for _, app := range apps {
+		patterns = append(patterns, app.Name()+".service")
+	}
+
+	units := map[string]systemd.UnitStatus{}
+
+	if len(patterns) > 0 {
+		statuses, err := systemd.ListUnitStatuses(context.Background(), patterns...)
+		if err != nil {
+			return "", err
+		}
+
+		for _, unit := range statuses {
+			units[unit.Name] = unit
+		}
+	}
+
+	for _, app := range apps {
+		fmt.Fprintf(&sb, "%s%s[white]\n", colorTag(t.theme.labelColor), app.Name())
+		fmt.Fprintf(&sb, "  Version: %s\n", app.FriendlyVersion())
+
+		installedAt := app.InstalledAt()
+		if installedAt != nil {
+			fmt.Fprintf(&sb, "  Installed: %s\n", installedAt.Format(time.DateTime))
+		}
+
+		ports := app.ListeningPorts()
+		if len(ports) > 0 {
+			portNames := make([]string, 0, len(ports))
+			for _, port := range ports {
+				portNames = append(portNames, strconv.Itoa(port))
+			}
+
+			fmt.Fprintf(&sb, "  Ports: %s\n", strings.Join(portNames, ", "))
+		}
+
+		unit, ok := units[app.Name()+".service"]
+		if ok {
+			switch {
+			case unit.Active == "failed":
+				sb.WriteString("  Status: " + colorTag(t.theme.errorColor) + "failed[white]\n")
+			case unit.Active == "active" && unit.Sub == "running":
+				sb.WriteString("  Status: running\n")
+			default:
+				sb.WriteString("  Status: " + colorTag(t.theme.warningColor) + "stopped[white]\n")
+			}
+		}
+
+		if app.IsPrimary() {
+			sb.WriteString("  Primary application\n")
+		}
+
+		if !app.IsInitialized() {
+			sb.WriteString("  " + colorTag(t.theme.warningColor) + "Not initialized yet[white]\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if len(apps) > 0 {
+		sb.WriteString("Press 'a' to restart or uninstall an application.\n")
+	}
+
+	return sb.String(), nil
+}
+
+// renderEventsView returns the content of the events view, newest first.
+func (t *TUI) renderEventsView() string {
+	events := t.state.GetEvents()
+
+	var sb strings.Builder
+
+	if len(events) == 0 {
+		sb.WriteString("No events have been recorded yet.\n")
+	}
+
+	for _, event := range slices.Backward(events) {
+		fmt.Fprintf(&sb, "%s %s%-12s[white] %s\n", event.Time.Local().Format(time.DateTime), colorTag(t.theme.labelColor), event.Type, tview.Escape(event.Message))
+	}
+
+	return sb.String()
+}
+
+// renderUnitsView returns the content of the systemd units view.
+func (t *TUI) renderUnitsView() (string, error) {
+	units, err := systemd.ListUnitStatuses(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s%-40s %-10s %-10s %s[white]\n", colorTag(t.theme.labelColor), "UNIT", "LOAD", "ACTIVE", "SUB")
+
+	for _, unit := range units {
+		failed := unit.Active == "failed"
+
+		// Only show the key units, applications and anything which failed.
+		if !failed && !slices.Contains(keyUnits, unit.Name) && !strings.HasPrefix(unit.Name, "incus") {
+			continue
+		}
+
+		line := fmt.Sprintf("%-40s %-10s %-10s %s", unit.Name, unit.Load, unit.Active, unit.Sub)
+		if failed {
+			line = colorTag(t.theme.errorColor) + line + "[white]"
+		}
+
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// How long a fatal failure is shown on the console before the daemon exits.
+const fatalExitDelay = 15 * time.Second
+
+// exitAfterFatal terminates the daemon after a fatal failure, letting systemd restart it.
+var exitAfterFatal = func() {
+	time.Sleep(fatalExitDelay)
+	os.Exit(1) //nolint:revive
+}
+
+// SafeGo runs fn in a new goroutine, reporting any panic on the console before exiting rather
+// than letting it crash the daemon with no explanation on screen.
+func SafeGo(name string, fn func()) {
+	go func() {
+		defer recoverPanic(name)
+
+		fn()
+	}()
+}
+
+// recoverPanic reports any ongoing panic of the named goroutine, then exits the daemon so it
+// gets restarted in a clean state. It must be deferred.
+func recoverPanic(name string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	ReportFatal(name, fmt.Errorf("panic: %v", r), debug.Stack())
+	exitAfterFatal()
+}
+
+// ReportFatal logs a fatal failure of the named part of the daemon along with its stack trace,
+// also raising an alert on the console if the TUI is running.
+func ReportFatal(name string, err error, stack []byte) {
+	slog.Error("Fatal failure in "+name, "err", err)
+
+	if len(stack) > 0 {
+		slog.Error("Stack trace of the failure in " + name + ":\n" + strings.TrimSpace(string(stack)))
+	}
+
+	t, tuiErr := GetTUI(nil)
+	if tuiErr != nil {
+		return
+	}
+
+	modal := t.AddModal("Daemon failure", "fatal-"+name)
+	modal.Update("[red]" + name + " has failed:[white] " + tview.Escape(err.Error()) + "\n\nThe full stack trace has been logged and the daemon will restart.")
+}
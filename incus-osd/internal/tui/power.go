@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// confirmReboot asks the user to confirm a system reboot, then triggers it.
+func (t *TUI) confirmReboot() {
+	confirmed, err := t.DisplayConfirm("Reboot", "Are you sure you want to reboot the system?")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("System reboot requested from the console")
+
+	// Prefer going through the daemon so applications are cleanly stopped.
+	if t.state.TriggerReboot != nil {
+		select {
+		case t.state.TriggerReboot <- true:
+		default:
+		}
+
+		return
+	}
+
+	err = systemd.SystemRebootNoBlock(context.Background())
+	if err != nil {
+		slog.Error("Failed to reboot the system", "err", err)
+	}
+}
+
+// confirmPowerOff asks the user to confirm a system power off, then triggers it.
+func (t *TUI) confirmPowerOff() {
+	confirmed, err := t.DisplayConfirm("Power off", "Are you sure you want to power off the system?")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("System power off requested from the console")
+
+	// Prefer going through the daemon so applications are cleanly stopped.
+	if t.state.TriggerShutdown != nil {
+		select {
+		case t.state.TriggerShutdown <- true:
+		default:
+		}
+
+		return
+	}
+
+	err = systemd.SystemPowerOffNoBlock(context.Background())
+	if err != nil {
+		slog.Error("Failed to power off the system", "err", err)
+	}
+}
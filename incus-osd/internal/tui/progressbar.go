@@ -5,6 +5,7 @@ package tui
  */
 
 import (
+	"fmt"
 	"math"
 	"sync"
 
@@ -33,6 +34,9 @@ type ProgressBar struct {
 	// from bottom to top.
 	vertical bool
 
+	// If set to true, the percentage of progress is displayed centered within the bar.
+	showPercent bool
+
 	// Current progress.
 	progress int64
 
@@ -95,6 +99,14 @@ func (p *ProgressBar) SetVertical(vertical bool) {
 	p.vertical = vertical
 }
 
+// SetShowPercent sets whether the percentage of progress is displayed within the bar.
+func (p *ProgressBar) SetShowPercent(showPercent bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.showPercent = showPercent
+}
+
 // SetMax sets the progress required to fill the bar.
 func (p *ProgressBar) SetMax(maxVal int64) {
 	p.Lock()
@@ -189,4 +201,25 @@ func (p *ProgressBar) Draw(screen tcell.Screen) {
 			}
 		}
 	}
+
+	if !p.showPercent || p.vertical || p.max <= 0 {
+		return
+	}
+
+	// Overlay the percentage, inverting the colors where it overlaps the filled area.
+	text := fmt.Sprintf("%d%%", p.progress*100/p.max)
+	if len(text) > width {
+		return
+	}
+
+	start := (width - len(text)) / 2
+
+	for i, r := range text {
+		style := tcell.StyleDefault.Foreground(p.filledColor).Background(p.GetBackgroundColor())
+		if start+i < barLength {
+			style = tcell.StyleDefault.Foreground(p.GetBackgroundColor()).Background(p.filledColor)
+		}
+
+		screen.SetContent(x+start+i, y+height/2, r, nil, style)
+	}
 }
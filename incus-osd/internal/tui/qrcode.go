@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/skip2/go-qrcode"
+)
+
+// DisplayQRCode shows a dialog with a QR code encoding the provided data, such as an enrollment URL.
+// If the console is too small to fit the QR code, the raw data is displayed instead. The dialog
+// is dismissed automatically once the system has been registered with its provider. This must not
+// be called from a TUI event handler.
+func (t *TUI) DisplayQRCode(title string, data string) error {
+	qr, err := qrcode.New(data, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	bitmap := qr.Bitmap()
+
+	// Each line of text holds two rows of the QR code, plus room for the border and data.
+	width := len(bitmap) + 2
+	height := (len(bitmap)+1)/2 + 4
+
+	consoleWidth, consoleHeight := t.screen.Size()
+	if width > consoleWidth || height > consoleHeight {
+		modal := tview.NewModal().
+			SetText(tview.Escape(data)).
+			AddButtons([]string{"Close"}).
+			SetDoneFunc(func(_ int, _ string) {
+				t.qrCodeShown.Store(false)
+				t.closeDialog()
+			})
+		modal.SetTitle(" " + title + " ")
+
+		t.qrCodeShown.Store(!t.state.System.Provider.State.Registered)
+
+		err = t.openDialog(modal, 0, 0)
+		if err != nil {
+			t.qrCodeShown.Store(false)
+
+			return err
+		}
+
+		return nil
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(renderQRCode(bitmap) + "\n" + tview.Escape(data)).
+		SetDoneFunc(func(_ tcell.Key) {
+			t.qrCodeShown.Store(false)
+			t.closeDialog()
+		})
+	view.SetBorder(true).SetTitle(" " + title + " ")
+
+	t.qrCodeShown.Store(!t.state.System.Provider.State.Registered)
+
+	err = t.openDialog(view, min(max(width, len(data)+2), consoleWidth), height)
+	if err != nil {
+		t.qrCodeShown.Store(false)
+
+		return err
+	}
+
+	return nil
+}
+
+// renderQRCode renders a QR code bitmap using half-block characters, two rows per line of text.
+func renderQRCode(bitmap [][]bool) string {
+	color := func(dark bool) string {
+		if dark {
+			return "black"
+		}
+
+		return "white"
+	}
+
+	var sb strings.Builder
+
+	for y := 0; y < len(bitmap); y += 2 {
+		for x := range bitmap[y] {
+			// Beyond the last row is part of the light quiet zone.
+			bottom := false
+			if y+1 < len(bitmap) {
+				bottom = bitmap[y+1][x]
+			}
+
+			sb.WriteString("[" + color(bitmap[y][x]) + ":" + color(bottom) + "]▀")
+		}
+
+		sb.WriteString("[-:-]\n")
+	}
+
+	return sb.String()
+}
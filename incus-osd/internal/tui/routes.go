@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// getDefaultGateways returns the default gateways from the kernel's routing tables, along
+// with the interface each is reached through.
+func getDefaultGateways() []string {
+	ret := []string{}
+
+	content, err := os.ReadFile("/proc/net/route")
+	if err == nil {
+		ret = append(ret, parseIPv4DefaultRoutes(string(content))...)
+	}
+
+	content, err = os.ReadFile("/proc/net/ipv6_route")
+	if err == nil {
+		ret = append(ret, parseIPv6DefaultRoutes(string(content))...)
+	}
+
+	return ret
+}
+
+// parseIPv4DefaultRoutes extracts the default gateways from the content of /proc/net/route.
+func parseIPv4DefaultRoutes(content string) []string {
+	ret := []string{}
+
+	for line := range strings.Lines(content) {
+		// Fields are "Iface Destination Gateway Flags RefCnt Use Metric Mask ...".
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+
+		gateway, err := strconv.ParseUint(fields[2], 16, 32)
+		if err != nil || gateway == 0 {
+			continue
+		}
+
+		// Addresses are in host byte order, which is little endian on all supported architectures.
+		var addr [4]byte
+
+		binary.LittleEndian.PutUint32(addr[:], uint32(gateway)) // #nosec G115
+
+		ret = append(ret, netip.AddrFrom4(addr).String()+" ("+fields[0]+")")
+	}
+
+	return ret
+}
+
+// parseIPv6DefaultRoutes extracts the default gateways from the content of /proc/net/ipv6_route.
+func parseIPv6DefaultRoutes(content string) []string {
+	ret := []string{}
+
+	for line := range strings.Lines(content) {
+		// Fields are "Destination PrefixLen Source PrefixLen NextHop Metric RefCnt Use Flags Iface".
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[1] != "00" || strings.Trim(fields[0], "0") != "" {
+			continue
+		}
+
+		nextHop, err := hex.DecodeString(fields[4])
+		if err != nil || len(nextHop) != 16 {
+			continue
+		}
+
+		addr := netip.AddrFrom16([16]byte(nextHop))
+		if addr.IsUnspecified() {
+			continue
+		}
+
+		ret = append(ret, addr.String()+" ("+fields[9]+")")
+	}
+
+	return ret
+}
+
+// getDNSServers returns the DNS servers currently used by systemd-resolved.
+func getDNSServers() []string {
+	content, err := os.ReadFile("/run/systemd/resolve/resolv.conf")
+	if err != nil {
+		return []string{}
+	}
+
+	return parseResolvConf(string(content))
+}
+
+// parseResolvConf returns the nameservers listed in a resolv.conf file.
+func parseResolvConf(content string) []string {
+	ret := []string{}
+
+	for line := range strings.Lines(content) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			ret = append(ret, fields[1])
+		}
+	}
+
+	return ret
+}
+
+// getCarrier returns whether a link is detected on the interface, preferring the underlying physical
+// device when there is one. The boolean is false if the carrier state can't be determined, for example
+// for virtual interfaces or interfaces which are down.
+func getCarrier(name string, hwaddr string) (bool, bool) {
+	devices := []string{name}
+	if hwaddr != "" {
+		devices = append([]string{"_p" + strings.ToLower(strings.ReplaceAll(hwaddr, ":", ""))}, devices...)
+	}
+
+	for _, device := range devices {
+		content, err := os.ReadFile("/sys/class/net/" + device + "/carrier") // #nosec G304
+		if err != nil {
+			continue
+		}
+
+		return strings.TrimSpace(string(content)) == "1", true
+	}
+
+	return false, false
+}
+
+// formatLinkSpeed formats a speed in Mb/s as reported by sysfs, returning an empty string if unknown.
+func formatLinkSpeed(speed string) string {
+	value, err := strconv.Atoi(speed)
+	if err != nil || value <= 0 {
+		return ""
+	}
+
+	if value >= 1000 && value%1000 == 0 {
+		return strconv.Itoa(value/1000) + " Gb/s"
+	}
+
+	return strconv.Itoa(value) + " Mb/s"
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// highlightMatches returns the line with all matches of the current search highlighted, each
+// in its own region so it can be scrolled to. Lines without matches are returned unmodified.
+func (t *TUI) highlightMatches(line string) string {
+	if t.searchQuery == "" {
+		return line
+	}
+
+	plain := stripColorTags(line)
+	haystack := plain
+	needle := t.searchQuery
+
+	if !t.searchCaseSensitive {
+		haystack = strings.ToLower(haystack)
+		needle = strings.ToLower(needle)
+	}
+
+	if !strings.Contains(haystack, needle) {
+		return line
+	}
+
+	var sb strings.Builder
+
+	for {
+		index := strings.Index(haystack, needle)
+		if index < 0 {
+			break
+		}
+
+		sb.WriteString(tview.Escape(plain[:index]))
+		fmt.Fprintf(&sb, `["%d"][black:yellow]%s[-:-][""]`, t.searchMatches, tview.Escape(plain[index:index+len(needle)]))
+		t.searchMatches++
+
+		plain = plain[index+len(needle):]
+		haystack = haystack[index+len(needle):]
+	}
+
+	sb.WriteString(tview.Escape(plain))
+
+	return sb.String()
+}
+
+// getSearchDescription returns a human-readable description of the active search, if any.
+func (t *TUI) getSearchDescription() string {
+	t.logMutex.Lock()
+	defer t.logMutex.Unlock()
+
+	if t.searchQuery == "" {
+		return ""
+	}
+
+	if t.searchMatches == 0 {
+		return "search: " + t.searchQuery + " (no matches)"
+	}
+
+	return fmt.Sprintf("search: %s (%d/%d)", t.searchQuery, t.searchCurrent+1, t.searchMatches)
+}
+
+// showSearch displays a dialog prompting for a string to search for in the log view.
+func (t *TUI) showSearch() {
+	t.logMutex.Lock()
+	query := t.searchQuery
+	caseSensitive := t.searchCaseSensitive
+	t.logMutex.Unlock()
+
+	form := tview.NewForm()
+	form.AddInputField("Search", query, 40, nil, func(text string) {
+		query = text
+	})
+	form.AddCheckbox("Case sensitive", caseSensitive, func(checked bool) {
+		caseSensitive = checked
+	})
+
+	form.AddButton("Search", func() {
+		t.closeDialog()
+		t.startSearch(query, caseSensitive)
+	})
+
+	form.SetCancelFunc(t.closeDialog)
+	form.SetTitle(" Search logs ").SetBorder(true)
+
+	t.showDialog(form, 60, 9)
+}
+
+// startSearch highlights all matches of the query in the log view and jumps to the most recent one.
+func (t *TUI) startSearch(query string, caseSensitive bool) {
+	if query == "" {
+		t.clearSearch()
+
+		return
+	}
+
+	t.logMutex.Lock()
+	t.searchQuery = query
+	t.searchCaseSensitive = caseSensitive
+	t.logMutex.Unlock()
+
+	t.renderLogView()
+
+	t.logMutex.Lock()
+	t.searchCurrent = max(t.searchMatches-1, 0)
+	t.logMutex.Unlock()
+
+	t.showSearchMatch()
+}
+
+// nextSearchMatch moves to the next (or previous) search match, wrapping around at either end.
+func (t *TUI) nextSearchMatch(offset int) {
+	t.logMutex.Lock()
+
+	if t.searchMatches == 0 {
+		t.logMutex.Unlock()
+
+		return
+	}
+
+	t.searchCurrent = (t.searchCurrent + offset + t.searchMatches) % t.searchMatches
+	t.logMutex.Unlock()
+
+	t.showSearchMatch()
+}
+
+// showSearchMatch highlights the current search match and scrolls the log view to it.
+func (t *TUI) showSearchMatch() {
+	t.logMutex.Lock()
+	matches := t.searchMatches
+	current := t.searchCurrent
+	t.logMutex.Unlock()
+
+	if matches > 0 {
+		t.setAutoScroll(false)
+		t.textView.Highlight(strconv.Itoa(current)).ScrollToHighlight()
+	}
+
+	t.requestRedraw()
+}
+
+// clearSearch removes any search highlighting and resumes following new log entries.
+func (t *TUI) clearSearch() {
+	t.logMutex.Lock()
+	t.searchQuery = ""
+	t.searchMatches = 0
+	t.searchCurrent = 0
+	t.logMutex.Unlock()
+
+	t.textView.Highlight()
+	t.renderLogView()
+
+	t.setAutoScroll(true)
+	t.textView.ScrollToEnd()
+
+	t.requestRedraw()
+}
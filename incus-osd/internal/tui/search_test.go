@@ -0,0 +1,21 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	t.Parallel()
+
+	tuiApp := &TUI{searchQuery: "error"}
+
+	line := "2026-01-01 00:00:00 [red]ERROR[white] Disk error[purple] dev=[sda][white]\n"
+	require.Equal(t, `2026-01-01 00:00:00 ["0"][black:yellow]ERROR[-:-][""] Disk ["1"][black:yellow]error[-:-][""] dev=[sda[]`+"\n", tuiApp.highlightMatches(line))
+	require.Equal(t, 2, tuiApp.searchMatches)
+
+	tuiApp.searchCaseSensitive = true
+	tuiApp.searchQuery = "ERROR!"
+	require.Equal(t, line, tuiApp.highlightMatches(line))
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Maximum number of security events to retain.
+const maxSecurityEvents = 100
+
+// securityEvent holds a single security-relevant event which must be acknowledged by the user.
+type securityEvent struct {
+	time         time.Time
+	message      string
+	count        int
+	acknowledged bool
+}
+
+// AddSecurityEvent records a security-relevant event. It remains flagged on the console until
+// acknowledged by the user. Repeated events are coalesced until acknowledged.
+func (t *TUI) AddSecurityEvent(message string) {
+	slog.Warn("Security event: " + message)
+
+	t.securityMutex.Lock()
+	defer t.securityMutex.Unlock()
+
+	for i := range t.securityEvents {
+		if !t.securityEvents[i].acknowledged && t.securityEvents[i].message == message {
+			t.securityEvents[i].time = time.Now()
+			t.securityEvents[i].count++
+
+			return
+		}
+	}
+
+	t.securityEvents = append(t.securityEvents, securityEvent{
+		time:    time.Now(),
+		message: message,
+		count:   1,
+	})
+
+	if len(t.securityEvents) > maxSecurityEvents {
+		t.securityEvents = t.securityEvents[len(t.securityEvents)-maxSecurityEvents:]
+	}
+}
+
+// getUnacknowledgedSecurityEvents returns the number of security events not yet acknowledged.
+func (t *TUI) getUnacknowledgedSecurityEvents() int {
+	t.securityMutex.Lock()
+	defer t.securityMutex.Unlock()
+
+	count := 0
+
+	for _, event := range t.securityEvents {
+		if !event.acknowledged {
+			count++
+		}
+	}
+
+	return count
+}
+
+// showSecurityEvents displays a dialog listing recent security events, allowing them to be acknowledged.
+func (t *TUI) showSecurityEvents() {
+	t.securityMutex.Lock()
+
+	var sb strings.Builder
+
+	if len(t.securityEvents) == 0 {
+		sb.WriteString("No security events have been recorded.")
+	}
+
+	// Show the ten most recent events, newest first.
+	for i, event := range slices.Backward(t.securityEvents) {
+		if i < len(t.securityEvents)-10 {
+			break
+		}
+
+		status := "[green]acknowledged[white]"
+		if !event.acknowledged {
+			status = "[red]new[white]"
+		}
+
+		fmt.Fprintf(&sb, "%s %s (%s", event.time.Format(time.DateTime), event.message, status)
+
+		if event.count > 1 {
+			fmt.Fprintf(&sb, ", seen %d times", event.count)
+		}
+
+		sb.WriteString(")\n")
+	}
+
+	t.securityMutex.Unlock()
+
+	modal := tview.NewModal().
+		SetText(t.colorize(sb.String())).
+		AddButtons([]string{"Acknowledge", "Close"}).
+		SetDoneFunc(func(_ int, label string) {
+			if label == "Acknowledge" {
+				t.securityMutex.Lock()
+
+				for i := range t.securityEvents {
+					t.securityEvents[i].acknowledged = true
+				}
+
+				t.securityMutex.Unlock()
+
+				t.requestRedraw()
+			}
+
+			t.closeDialog()
+		})
+	modal.SetTitle(" Security events ")
+
+	t.showDialog(modal, 0, 0)
+}
+
+// getSecurityStatus returns the Secure Boot and TPM status, highlighting any degraded state.
+func (t *TUI) getSecurityStatus() string {
+	warning := colorTag(t.theme.warningColor)
+
+	secureBoot := "n/a"
+
+	_, err := os.Stat("/sys/firmware/efi")
+	if err == nil {
+		secureBoot = "[green]enabled[white]"
+		if t.state.SecureBootDisabled {
+			secureBoot = warning + "disabled[white]"
+		}
+	}
+
+	tpm := warning + "not found[white]"
+
+	_, err = os.Stat("/dev/tpmrm0")
+
+	switch {
+	case t.state.UsingSWTPM:
+		tpm = warning + "swtpm[white]"
+	case err == nil:
+		tpm = "[green]present[white]"
+	default:
+	}
+
+	status := "Secure Boot " + secureBoot + ", TPM " + tpm
+
+	switch t.state.System.Update.State.ImageSignature {
+	case "verified":
+		status += ", image signature [green]verified[white]"
+	case "failed":
+		status += ", image signature " + colorTag(t.theme.errorColor) + "failed[white]"
+	default:
+	}
+
+	return status
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Location of the machine-readable snapshot of the console's status, updated on redraws when it changes.
+const statusSnapshotPath = "/run/incus-os/status.json"
+
+// StatusSnapshot holds the information shown on the console, in a machine-readable form.
+type StatusSnapshot struct {
+	Time      time.Time            `json:"time"`
+	Hostname  string               `json:"hostname"`
+	OSName    string               `json:"os_name"`
+	OSRelease string               `json:"os_release"`
+	Status    []StatusSnapshotLine `json:"status"`
+}
+
+// StatusSnapshotLine holds a single status line, as shown in the footer or on the dashboard.
+type StatusSnapshotLine struct {
+	Label string `json:"label,omitempty"`
+	Text  string `json:"text"`
+}
+
+// GetStatusSnapshot returns the current status shown on the console, from the top downwards.
+func (t *TUI) GetStatusSnapshot() StatusSnapshot {
+	hostname, _ := os.Hostname()
+
+	ret := StatusSnapshot{
+		Time:      time.Now().UTC(),
+		Hostname:  hostname,
+		OSName:    t.state.OS.Name,
+		OSRelease: t.state.OS.RunningRelease,
+		Status:    []StatusSnapshotLine{},
+	}
+
+	lines := t.getStatusLines()
+	slices.Reverse(lines)
+
+	for _, line := range lines {
+		ret.Status = append(ret.Status, StatusSnapshotLine{Label: line.label, Text: tview.Unescape(t.stripThemeColors(line.text))})
+	}
+
+	return ret
+}
+
+// writeStatusSnapshot atomically replaces the status snapshot file with the current status, if it
+// changed since the last write.
+func (t *TUI) writeStatusSnapshot() {
+	snapshot := t.GetStatusSnapshot()
+
+	// Leave out the timestamp when comparing, so it reflects when the status last changed.
+	compare := snapshot
+	compare.Time = time.Time{}
+
+	content, err := json.Marshal(compare)
+	if err != nil || bytes.Equal(content, t.lastStatusSnapshot) {
+		return
+	}
+
+	body, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(statusSnapshotPath), 0o755)
+	if err == nil {
+		err = os.WriteFile(statusSnapshotPath+".tmp", append(body, '\n'), 0o600)
+	}
+
+	if err == nil {
+		err = os.Rename(statusSnapshotPath+".tmp", statusSnapshotPath)
+	}
+
+	if err != nil {
+		// Only report the first failure, as the snapshot is written on every redraw.
+		if !t.statusSnapshotFailed {
+			slog.Warn("Failed to write the status snapshot", "err", err)
+		}
+
+		t.statusSnapshotFailed = true
+
+		return
+	}
+
+	t.lastStatusSnapshot = content
+	t.statusSnapshotFailed = false
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Number of samples retained for the CPU sparkline, one per redraw.
+const cpuSparklineSamples = 12
+
+// Block characters used to draw sparklines, from lowest to highest.
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders the most recent samples which fit within the width, scaled between zero and
+// the provided maximum, or the largest sample if no maximum is given.
+func sparkline(samples []float64, width int, maximum float64) string {
+	if width <= 0 || len(samples) == 0 {
+		return ""
+	}
+
+	samples = samples[max(len(samples)-width, 0):]
+
+	if maximum <= 0 {
+		for _, sample := range samples {
+			maximum = max(maximum, sample)
+		}
+	}
+
+	var sb strings.Builder
+
+	for _, sample := range samples {
+		index := 0
+		if maximum > 0 {
+			index = int(sample / maximum * float64(len(sparklineBlocks)-1))
+		}
+
+		sb.WriteRune(sparklineBlocks[min(max(index, 0), len(sparklineBlocks)-1)])
+	}
+
+	return sb.String()
+}
+
+// cpuSampler keeps a short history of the CPU usage, as a percentage of the time spent busy
+// between consecutive samples.
+type cpuSampler struct {
+	mutex     sync.Mutex
+	samples   []float64
+	lastTotal uint64
+	lastIdle  uint64
+}
+
+// Sample records the CPU usage since the previous sample.
+func (c *cpuSampler) Sample() {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return
+	}
+
+	defer f.Close()
+
+	total, idle, err := parseCPUStat(f)
+	if err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// The first sample only provides the baseline.
+	if c.lastTotal > 0 && total > c.lastTotal {
+		busy := float64((total-c.lastTotal)-(idle-c.lastIdle)) / float64(total-c.lastTotal) * 100
+
+		c.samples = append(c.samples, busy)
+		if len(c.samples) > cpuSparklineSamples {
+			c.samples = c.samples[1:]
+		}
+	}
+
+	c.lastTotal = total
+	c.lastIdle = idle
+}
+
+// Samples returns a copy of the recorded samples, oldest first.
+func (c *cpuSampler) Samples() []float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]float64{}, c.samples...)
+}
+
+// parseCPUStat returns the total and idle (including I/O wait) time of all CPUs from /proc/stat.
+func parseCPUStat(r io.Reader) (uint64, uint64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || fields[0] != "cpu" {
+			continue
+		}
+
+		var total, idle uint64
+
+		for i, field := range fields[1:] {
+			// Guest time is already accounted for in user and nice time.
+			if i >= 8 {
+				break
+			}
+
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return 0, 0, err
+			}
+
+			total += value
+
+			// Fields are user, nice, system, idle, iowait, irq, softirq and steal.
+			if i == 3 || i == 4 {
+				idle += value
+			}
+		}
+
+		return total, idle, nil
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return 0, 0, errors.New("no cpu line in /proc/stat")
+}
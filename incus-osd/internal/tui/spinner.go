@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// How often the spinner of a busy modal advances.
+var spinnerInterval = 250 * time.Millisecond
+
+// Frames used to animate spinners.
+var (
+	spinnerFrames      = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+	spinnerFramesASCII = []rune(`|/-\`)
+)
+
+// Spinner indicates that an operation of unknown duration is in progress.
+type Spinner struct {
+	*tview.Box
+	sync.RWMutex
+
+	// Runes making up the animation.
+	frames []rune
+
+	// Index of the frame currently displayed.
+	current int
+
+	// Color of the spinner.
+	color tcell.Color
+}
+
+// NewSpinner returns a new spinner.
+func NewSpinner() *Spinner {
+	s := &Spinner{
+		Box:    tview.NewBox(),
+		frames: spinnerFrames,
+		color:  tview.Styles.PrimaryTextColor,
+	}
+	s.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+
+	return s
+}
+
+// SetFrames sets the runes making up the animation.
+func (s *Spinner) SetFrames(frames []rune) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.frames = frames
+	s.current %= len(frames)
+}
+
+// SetFrame sets the frame currently displayed, wrapping around once the end of the animation is reached.
+func (s *Spinner) SetFrame(frame int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.current = frame % len(s.frames)
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Spinner) Draw(screen tcell.Screen) {
+	s.Box.Draw(screen)
+
+	s.RLock()
+	defer s.RUnlock()
+
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	screen.SetContent(x+width/2, y+height/2, s.frames[s.current], nil, tcell.StyleDefault.Foreground(s.color).Background(s.GetBackgroundColor()))
+}
+
+// newSpinner returns a spinner suitable for the current console.
+func (t *TUI) newSpinner() *Spinner {
+	spinner := NewSpinner()
+
+	if t.serialConsole {
+		spinner.SetFrames(spinnerFramesASCII)
+	}
+
+	return spinner
+}
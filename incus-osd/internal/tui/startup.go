@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// How often the screen is re-drawn while the system is starting up.
+const startupRedrawInterval = 2 * time.Second
+
+// bootTargets lists the systemd targets shown in the startup view, in the order they're reached.
+var bootTargets = []string{
+	"local-fs.target",
+	"sysinit.target",
+	"basic.target",
+	"network-online.target",
+	"multi-user.target",
+}
+
+// startingUp returns true while the daemon hasn't finished its startup tasks.
+func (t *TUI) startingUp() bool {
+	return !t.state.ShouldPerformInstall && !t.state.OS.SystemIsReady
+}
+
+// renderStartupView returns the content of the startup view, listing which boot targets have been reached.
+func (t *TUI) renderStartupView() string {
+	units := map[string]systemd.UnitStatus{}
+
+	statuses, err := systemd.ListUnitStatuses(context.Background(), bootTargets...)
+	if err == nil {
+		for _, unit := range statuses {
+			units[unit.Name] = unit
+		}
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s%s is starting up...[white]\n\n", colorTag(t.theme.labelColor), t.state.OS.Name)
+
+	for _, target := range bootTargets {
+		unit, ok := units[target]
+
+		switch {
+		case ok && unit.Active == "active":
+			fmt.Fprintf(&sb, "  [green]%-10s[white] %s\n", "reached", target)
+		case ok && unit.Active == "failed":
+			fmt.Fprintf(&sb, "  %s%-10s[white] %s\n", colorTag(t.theme.errorColor), "failed", target)
+		default:
+			fmt.Fprintf(&sb, "  %s%-10s[white] %s\n", colorTag(t.theme.warningColor), "pending", target)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n  %s%-10s[white] %s\n", colorTag(t.theme.warningColor), "pending", "incus-osd startup tasks")
+
+	return sb.String()
+}
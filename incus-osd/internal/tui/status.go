@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
+)
+
+// statusLine holds a single piece of status information, either shown in the footer or
+// on the dashboard. Lines without a label are rendered as-is in the given color.
+type statusLine struct {
+	label string
+	text  string
+	color tcell.Color
+}
+
+// getStatusLines returns the current status lines, ordered from the bottom of the footer upwards.
+func (t *TUI) getStatusLines() []statusLine {
+	ret := []statusLine{}
+
+	// Don't display the system status during install.
+	if !t.state.ShouldPerformInstall {
+		// Get list of applications from state.
+		apps, err := applications.GetInstalled(context.Background(), t.state)
+
+		appStatus := []string{}
+		for _, app := range apps {
+			appStatus = append(appStatus, app.Name()+"("+app.FriendlyVersion()+")")
+		}
+
+		slices.Sort(appStatus)
+
+		// Flag any failure rather than skipping the whole redraw.
+		if err != nil {
+			appStatus = append(appStatus, colorTag(t.theme.errorColor)+"unable to list applications: "+tview.Escape(err.Error())+"[white]")
+		}
+
+		// Lines registered by other parts of the daemon are shown at the bottom.
+		ret = append(ret, t.getFooterSections()...)
+
+		ret = append(ret,
+			statusLine{label: t.translate("DNS servers"), text: joinOrNone(getDNSServers())},
+			statusLine{label: t.translate("Default gateway"), text: joinOrNone(getDefaultGateways())},
+			statusLine{label: t.translate("Connectivity"), text: t.getConnectivityStatus()},
+			statusLine{label: t.translate("Network configuration"), text: strings.Join(t.getIPAddresses(), ", ")},
+			statusLine{label: t.translate("Machine"), text: getMachineInfo(t.systemResources)},
+			statusLine{label: t.translate("Security"), text: t.getSecurityStatus()},
+			statusLine{label: t.translate("Resources"), text: t.getResourceStatus()},
+			statusLine{label: t.translate("Installed application(s)"), text: strings.Join(appStatus, ", ")},
+			statusLine{label: t.translate("Enrollment"), text: t.getEnrollmentStatus()},
+			statusLine{label: t.translate("OS release"), text: t.getReleaseStatus()},
+		)
+
+		hardware := getHardwareInfo(t.showSerial.Load())
+		if hardware != "" {
+			ret = append(ret, statusLine{label: t.translate("Hardware"), text: tview.Escape(hardware)})
+		}
+
+		uptime, err := getUptime()
+		if err == nil {
+			ret = append(ret, statusLine{label: t.translate("Uptime"), text: uptime})
+		}
+
+		if !t.state.System.Security.State.EncryptionRecoveryKeysRetrieved {
+			ret = append(ret, statusLine{text: "WARNING: Some encryption recovery keys have not been retrieved yet!", color: t.theme.errorColor})
+		}
+	}
+
+	return ret
+}
+
+// getReleaseStatus returns the running release and update channel, along with any newer release
+// already applied by the update checker and waiting for a reboot.
+func (t *TUI) getReleaseStatus() string {
+	ret := t.state.OS.RunningRelease
+
+	if t.state.System.Update.Config.Channel != "" {
+		ret += " (channel: " + t.state.System.Update.Config.Channel + ")"
+	}
+
+	if t.state.System.Update.State.NeedsReboot && t.state.OS.RunningFromBackup() {
+		ret += " (update available: " + t.state.OS.NextRelease + ")"
+	}
+
+	return ret
+}
+
+// getEnrollmentStatus returns whether the system has been registered with its provider, highlighting pending registrations.
+func (t *TUI) getEnrollmentStatus() string {
+	provider := t.state.System.Provider
+
+	if provider.Config.Name == "" || provider.Config.Name == "local" {
+		return "Not enrolled"
+	}
+
+	if !provider.State.Registered {
+		return colorTag(t.theme.warningColor) + "Awaiting approval[white]"
+	}
+
+	server := provider.Config.Config["server_url"]
+	if server == "" {
+		server = provider.Config.Name
+	}
+
+	return "[green]Enrolled to " + tview.Escape(server) + "[white]"
+}
+
+// getResourceStatus returns the current resource usage, along with the recent CPU usage if enabled.
+func (t *TUI) getResourceStatus() string {
+	ret := getResourceUsage()
+
+	samples := t.cpuUsage.Samples()
+	if t.config.showSparkline && len(samples) > 0 {
+		ret += fmt.Sprintf(", cpu %s %.0f%%", sparkline(samples, cpuSparklineSamples, 100), samples[len(samples)-1])
+	}
+
+	return ret
+}
+
+// joinOrNone returns a comma separated list of the values, or "(none)" if there are none.
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// renderStatusLines formats the status lines for display in a text view, from top to bottom.
+func renderStatusLines(lines []statusLine, labelColor tcell.Color) string {
+	var sb strings.Builder
+
+	for _, line := range slices.Backward(lines) {
+		if line.label == "" {
+			sb.WriteString("[" + line.color.String() + "]" + line.text + "[white]\n\n")
+
+			continue
+		}
+
+		sb.WriteString(colorTag(labelColor) + line.label + ":[white] " + line.text + "\n\n")
+	}
+
+	return sb.String()
+}
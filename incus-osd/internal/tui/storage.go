@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v7/shared/units"
+	"golang.org/x/sys/unix"
+)
+
+// Filesystem usage percentages above which the storage view highlights a filesystem.
+const (
+	storageWarningThreshold = 80
+	storageErrorThreshold   = 90
+)
+
+// blockDevice holds the details of a block device or partition shown in the storage view.
+type blockDevice struct {
+	name       string
+	size       int64
+	fsType     string
+	mountpoint string
+	used       int64
+	total      int64
+}
+
+// mountEntry holds the filesystem type and mountpoint of a mounted block device.
+type mountEntry struct {
+	fsType     string
+	mountpoint string
+}
+
+// parseProcPartitions parses the content of /proc/partitions, returning each block device along with its size.
+func parseProcPartitions(r io.Reader) ([]blockDevice, error) {
+	devices := []blockDevice{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+
+		// Sizes are reported in 1KiB blocks, which also skips the header line.
+		blocks, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		devices = append(devices, blockDevice{name: fields[3], size: blocks * 1024})
+	}
+
+	return devices, scanner.Err()
+}
+
+// parseMounts parses the content of /proc/self/mounts, returning the first mount of each block device.
+func parseMounts(r io.Reader) (map[string]mountEntry, error) {
+	mounts := map[string]mountEntry{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+
+		_, ok := mounts[fields[0]]
+		if ok {
+			continue
+		}
+
+		mounts[fields[0]] = mountEntry{fsType: fields[2], mountpoint: fields[1]}
+	}
+
+	return mounts, scanner.Err()
+}
+
+// getBlockDevices returns all block devices and partitions, along with the usage of those which are mounted.
+func getBlockDevices() ([]blockDevice, error) {
+	partitions, err := os.Open("/proc/partitions")
+	if err != nil {
+		return nil, err
+	}
+
+	defer partitions.Close()
+
+	devices, err := parseProcPartitions(partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	mountsFile, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		return nil, err
+	}
+
+	defer mountsFile.Close()
+
+	mounts, err := parseMounts(mountsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, device := range devices {
+		path := "/dev/" + device.name
+
+		// Device mapper devices are mounted through their /dev/mapper/ name.
+		dmName, err := os.ReadFile(filepath.Join("/sys/block", device.name, "dm", "name"))
+		if err == nil {
+			path = "/dev/mapper/" + strings.TrimSpace(string(dmName))
+		}
+
+		mount, ok := mounts[path]
+		if !ok {
+			continue
+		}
+
+		devices[i].fsType = mount.fsType
+		devices[i].mountpoint = mount.mountpoint
+
+		var fs unix.Statfs_t
+
+		err = unix.Statfs(mount.mountpoint, &fs)
+		if err == nil && fs.Blocks > 0 {
+			devices[i].total = int64(fs.Blocks) * fs.Bsize         // #nosec G115
+			devices[i].used = int64(fs.Blocks-fs.Bfree) * fs.Bsize // #nosec G115
+		}
+	}
+
+	return devices, nil
+}
+
+// renderStorageView returns the content of the storage view.
+func (t *TUI) renderStorageView() (string, error) {
+	devices, err := getBlockDevices()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s%-20s %-10s %-10s %-30s %s[white]\n", colorTag(t.theme.labelColor), "DEVICE", "SIZE", "FS TYPE", "MOUNTPOINT", "USED/TOTAL")
+
+	for _, device := range devices {
+		usage := ""
+		if device.total > 0 {
+			usage = fmt.Sprintf("%s/%s (%d%%)", units.GetByteSizeStringIEC(device.used, 1), units.GetByteSizeStringIEC(device.total, 1), device.used*100/device.total)
+		}
+
+		line := fmt.Sprintf("%-20s %-10s %-10s %-30s %s", device.name, units.GetByteSizeStringIEC(device.size, 1), device.fsType, device.mountpoint, usage)
+
+		// Highlight filesystems which are close to running out of space.
+		switch {
+		case device.total > 0 && device.used*100/device.total >= storageErrorThreshold:
+			line = colorTag(t.theme.errorColor) + line + "[white]"
+		case device.total > 0 && device.used*100/device.total >= storageWarningThreshold:
+			line = colorTag(t.theme.warningColor) + line + "[white]"
+		default:
+		}
+
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String(), nil
+}
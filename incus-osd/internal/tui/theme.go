@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// theme holds the colors used throughout the TUI.
+type theme struct {
+	labelColor   tcell.Color
+	headerColor  tcell.Color
+	warningColor tcell.Color
+	errorColor   tcell.Color
+	borderColor  tcell.Color
+}
+
+// themes lists the available color themes, selectable by name.
+var themes = map[string]theme{
+	"default": {
+		labelColor:   tcell.ColorGreen,
+		headerColor:  tcell.ColorWhite,
+		warningColor: tcell.ColorYellow,
+		errorColor:   tcell.ColorRed,
+		borderColor:  tcell.ColorWhite,
+	},
+	"high-contrast": {
+		labelColor:   tcell.ColorAqua,
+		headerColor:  tcell.ColorWhite,
+		warningColor: tcell.ColorYellow,
+		errorColor:   tcell.ColorFuchsia,
+		borderColor:  tcell.ColorYellow,
+	},
+}
+
+// getTheme returns the theme with the given name, or the default theme if it doesn't exist.
+func getTheme(name string) theme {
+	th, ok := themes[name]
+	if !ok {
+		return themes["default"]
+	}
+
+	return th
+}
+
+// colorTag returns the tview color tag for the provided color.
+func colorTag(color tcell.Color) string {
+	return "[" + color.String() + "]"
+}
+
+// colorizeLogLine applies the theme's warning and error colors to the level of a log line.
+func (th theme) colorizeLogLine(line string) string {
+	line = strings.Replace(line, "[yellow]WARN", colorTag(th.warningColor)+"WARN", 1)
+	line = strings.Replace(line, "[red]ERROR", colorTag(th.errorColor)+"ERROR", 1)
+
+	return line
+}
+
+// colorize returns the text unchanged, or with all color tags removed if the console can't display colors.
+func (t *TUI) colorize(s string) string {
+	if !t.noColor {
+		return s
+	}
+
+	return t.stripThemeColors(s)
+}
+
+// stripThemeColors removes the color tags of log entries and of the current theme from the provided text.
+func (t *TUI) stripThemeColors(s string) string {
+	s = stripColorTags(s)
+
+	for _, color := range []tcell.Color{t.theme.labelColor, t.theme.headerColor, t.theme.warningColor, t.theme.errorColor} {
+		s = strings.ReplaceAll(s, colorTag(color), "")
+	}
+
+	return s
+}
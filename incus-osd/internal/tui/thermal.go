@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// thermalStatus holds the most recent thermal readings of the system.
+type thermalStatus struct {
+	hottestZone   string
+	hottestTemp   float64
+	isHot         bool
+	throttleCount uint64
+	isThrottling  bool
+	sampled       bool
+}
+
+// getThermalStatus reads the thermal zones and CPU throttle counters from sysfs. The previous
+// status is used to detect if any throttling has occurred since the last check.
+func getThermalStatus(prior thermalStatus) thermalStatus {
+	return readThermalStatus("/sys", prior)
+}
+
+// readThermalStatus reads the thermal status from the sysfs tree mounted at root.
+func readThermalStatus(root string, prior thermalStatus) thermalStatus {
+	ret := thermalStatus{sampled: true}
+
+	// Check each thermal zone, recording the hottest one.
+	zones, _ := filepath.Glob(filepath.Join(root, "class/thermal/thermal_zone*"))
+	for _, zone := range zones {
+		temp, err := readSysfsInt(filepath.Join(zone, "temp"))
+		if err != nil {
+			continue
+		}
+
+		// Values are reported in millidegrees Celsius.
+		tempC := float64(temp) / 1000
+
+		if ret.hottestZone == "" || tempC > ret.hottestTemp {
+			ret.hottestZone = readSysfsString(filepath.Join(zone, "type"))
+			ret.hottestTemp = tempC
+		}
+
+		if tempC >= getThermalThreshold(zone) {
+			ret.isHot = true
+		}
+	}
+
+	// Sum up the per-CPU throttle counters (only available on x86).
+	counters, _ := filepath.Glob(filepath.Join(root, "devices/system/cpu/cpu*/thermal_throttle/*_throttle_count"))
+	for _, counter := range counters {
+		count, err := readSysfsInt(counter)
+		if err != nil || count < 0 {
+			continue
+		}
+
+		ret.throttleCount += uint64(count) // #nosec G115
+	}
+
+	// Throttling is considered active if the counters increased since the last check. The
+	// first check only records a baseline, as the counters cover the whole uptime.
+	ret.isThrottling = prior.sampled && ret.throttleCount > prior.throttleCount
+
+	return ret
+}
+
+// getThermalThreshold returns the temperature in Celsius at which a zone is considered too hot.
+// This is 10 degrees below the lowest "hot" or "critical" trip point, or 85C if none is defined.
+func getThermalThreshold(zone string) float64 {
+	threshold := 0.0
+
+	trips, _ := filepath.Glob(filepath.Join(zone, "trip_point_*_type"))
+	for _, trip := range trips {
+		tripType := readSysfsString(trip)
+		if tripType != "hot" && tripType != "critical" {
+			continue
+		}
+
+		temp, err := readSysfsInt(strings.TrimSuffix(trip, "_type") + "_temp")
+		if err != nil || temp <= 0 {
+			continue
+		}
+
+		tempC := float64(temp)/1000 - 10
+		if threshold == 0 || tempC < threshold {
+			threshold = tempC
+		}
+	}
+
+	if threshold == 0 {
+		return 85
+	}
+
+	return threshold
+}
+
+// String returns a human-readable warning if the system is too hot or throttling, or an empty string otherwise.
+func (ts thermalStatus) String() string {
+	reading := fmt.Sprintf("%s at %.0f°C", ts.hottestZone, ts.hottestTemp)
+
+	switch {
+	case ts.isThrottling:
+		return "WARNING: CPU thermal throttling is active (hottest zone: " + reading + ")"
+	case ts.isHot:
+		return "WARNING: High system temperature detected (hottest zone: " + reading + ")"
+	default:
+		return ""
+	}
+}
+
+func readSysfsString(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+func readSysfsInt(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
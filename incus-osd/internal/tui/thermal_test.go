@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeSysfsFile(t *testing.T, path string, value string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(value+"\n"), 0o600))
+}
+
+func TestThermalStatus(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	counter := filepath.Join(root, "devices/system/cpu/cpu0/thermal_throttle/core_throttle_count")
+
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone0/type"), "x86_pkg_temp")
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone0/temp"), "45000")
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone1/type"), "acpitz")
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone1/temp"), "30000")
+	writeSysfsFile(t, counter, "0")
+
+	// The first check only records a baseline.
+	status := readThermalStatus(root, thermalStatus{})
+	require.True(t, status.sampled)
+	require.False(t, status.isThrottling)
+	require.False(t, status.isHot)
+	require.Equal(t, "x86_pkg_temp", status.hottestZone)
+	require.InDelta(t, 45.0, status.hottestTemp, 0.01)
+	require.Empty(t, status.String())
+
+	// The first throttling event since boot is reported.
+	writeSysfsFile(t, counter, "3")
+
+	status = readThermalStatus(root, status)
+	require.True(t, status.isThrottling)
+	require.Equal(t, "WARNING: CPU thermal throttling is active (hottest zone: x86_pkg_temp at 45°C)", status.String())
+
+	// Throttling clears once the counters stop increasing.
+	status = readThermalStatus(root, status)
+	require.False(t, status.isThrottling)
+
+	// Zones above their trip point are reported as too hot.
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone0/trip_point_0_type"), "critical")
+	writeSysfsFile(t, filepath.Join(root, "class/thermal/thermal_zone0/trip_point_0_temp"), "50000")
+
+	status = readThermalStatus(root, status)
+	require.True(t, status.isHot)
+	require.Equal(t, "WARNING: High system temperature detected (hottest zone: x86_pkg_temp at 45°C)", status.String())
+}
@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// How often the log view is re-drawn at most while log entries are being written.
+const logDrawInterval = 100 * time.Millisecond
+
+// throttle coalesces bursts of requests into a single call, made at most once per interval.
+type throttle struct {
+	fn       func()
+	interval time.Duration
+	pending  atomic.Bool
+}
+
+// newThrottle returns a throttle calling fn at most once per interval.
+func newThrottle(interval time.Duration, fn func()) *throttle {
+	return &throttle{fn: fn, interval: interval}
+}
+
+// Request schedules a call once the interval has elapsed, unless one is already pending.
+func (th *throttle) Request() {
+	if !th.pending.CompareAndSwap(false, true) {
+		return
+	}
+
+	time.AfterFunc(th.interval, func() {
+		th.pending.Store(false)
+		th.fn()
+	})
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	th := newThrottle(50*time.Millisecond, func() {
+		calls.Add(1)
+	})
+
+	// A burst of requests only results in a single call.
+	for range 5000 {
+		th.Request()
+	}
+
+	require.Eventually(t, func() bool {
+		return calls.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(1), calls.Load())
+
+	// Later requests are handled again.
+	th.Request()
+
+	require.Eventually(t, func() bool {
+		return calls.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+}
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -20,15 +22,25 @@ func newTtyMultiplexer(ttys ...string) (ttyMultiplexer, error) {
 		return ret, errors.New("at least one tty must be provided")
 	}
 
+	errs := []error{}
+
+	// Skip any tty which can't be opened, only failing if none are usable.
 	for _, tty := range ttys {
 		ttyDev, err := tcell.NewDevTtyFromDev(tty)
 		if err != nil {
+			slog.Warn("Skipping unusable console device", "device", tty, "err", err)
+			errs = append(errs, fmt.Errorf("%s: %w", tty, err))
+
 			continue
 		}
 
 		ret.ttys = append(ret.ttys, ttyDev)
 	}
 
+	if len(ret.ttys) == 0 {
+		return ret, fmt.Errorf("no usable tty found: %w", errors.Join(errs...))
+	}
+
 	return ret, nil
 }
 
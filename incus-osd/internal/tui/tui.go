@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
 	"os"
-	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -17,11 +21,13 @@ import (
 	"github.com/lxc/incus/v7/shared/units"
 	"github.com/rivo/tview"
 
-	"github.com/lxc/incus-os/incus-osd/internal/applications"
 	"github.com/lxc/incus-os/incus-osd/internal/state"
 	"github.com/lxc/incus-os/incus-osd/internal/systemd"
 )
 
+// journalPrefillEntries is the number of journal entries the log view is seeded with.
+const journalPrefillEntries = 100
+
 var (
 	singletonMutex sync.Mutex
 	singletonTUI   *TUI
@@ -35,12 +41,73 @@ type TUI struct {
 	pages    *tview.Pages
 	screen   tcell.Screen
 	textView *tview.TextView
-
-	modalMessages []*Modal
-	modalMutex    sync.Mutex
+	logDraw  *throttle
+
+	config         config
+	theme          theme
+	statusView     *tview.TextView
+	detailView     *tview.TextView
+	activeView     atomic.Int64
+	redrawRequests chan struct{}
+	width          int
+	height         int
+	showLogs       atomic.Bool
+
+	consoleClear  atomic.Bool
+	showMACs      atomic.Bool
+	showSerial    atomic.Bool
+	dimLogs       atomic.Bool
+	qrCodeShown   atomic.Bool
+	consoleErr    error
+	serialConsole bool
+	noColor       bool
+
+	autoScroll    atomic.Bool
+	pausedLines   int
+	lastLogRender time.Time
+
+	warningCount atomic.Int64
+	errorCount   atomic.Int64
+	lastWrite    atomic.Int64
+
+	modalMessages     []*Modal
+	modalMutex        sync.Mutex
+	currentModal      *Modal
+	currentModalTitle string
+	modalPage         tview.Primitive
+	modalPagePending  bool
+
+	securityEvents []securityEvent
+	securityMutex  sync.Mutex
+
+	statusBanner      string
+	statusBannerColor tcell.Color
+	statusBannerMutex sync.Mutex
+
+	footerSections map[string]footerSection
+	footerMutex    sync.Mutex
+
+	logs         *logBuffer
+	logFile      *logFile
+	stdout       io.Writer
+	logMutex     sync.Mutex
+	writeMutex   sync.Mutex
+	sourceFilter []string
+	levelFilter  slog.Level
+
+	searchQuery         string
+	searchCaseSensitive bool
+	searchMatches       int
+	searchCurrent       int
 
 	state           *state.State
 	systemResources *api.Resources
+	thermal         thermalStatus
+	connectivity    atomic.Pointer[connectivityStatus]
+	cpuUsage        cpuSampler
+
+	lastStatusSnapshot   []byte
+	statusSnapshotFailed bool
 }
 
 // GetTUI returns a singleton TUI application that will show basic information and recent
@@ -60,87 +127,355 @@ func GetTUI(s *state.State) (*TUI, error) {
 		return nil, errors.New("state cannot be nil")
 	}
 
-	singletonTUI = &TUI{
-		state: s,
+	serialConsole := isSerialConsole()
+
+	// A comma separated list of console devices replaces the automatically detected ones.
+	customTtys := os.Getenv("INCUSOS_TUI_TTYS")
+	if customTtys != "" {
+		ttyDevs = parseTtyDevs(customTtys)
+		if len(ttyDevs) == 0 {
+			return nil, errors.New("INCUSOS_TUI_TTYS must list at least one tty")
+		}
+	} else {
+		ttyDevs = getDefaultTtyDevs(s, serialConsole)
+	}
+
+	// Get information about the system's resources. Since we only display CPU
+	// and RAM, caching the results at creation time should be sufficient.
+	systemResources, err := resources.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a usable console (e.g. when running in a container), draw to an in-memory screen
+	// instead so modals and other TUI users keep working.
+	screen, consoleErr := newConsoleScreen()
+	if consoleErr != nil {
+		screen = tcell.NewSimulationScreen("UTF-8")
+	}
+
+	singletonTUI = newTUI(s, screen, serialConsole)
+	singletonTUI.systemResources = systemResources
+	singletonTUI.consoleErr = consoleErr
+
+	// Show the daemon's recent log entries from earlier in this boot.
+	singletonTUI.prefillLogs()
+
+	return singletonTUI, nil
+}
+
+// getDefaultTtyDevs returns the console devices to display the TUI on when not explicitly configured.
+func getDefaultTtyDevs(s *state.State, serialConsole bool) []string {
+	devs := slices.Clone(ttyDevs)
+
+	// Serial consoles have no virtual terminals to mirror to.
+	if serialConsole {
+		devs = slices.DeleteFunc(devs, func(dev string) bool {
+			return dev == "/dev/tty1"
+		})
 	}
 
 	// If we're running in an Incus VM, additionally use /dev/ttyS0.
 	_, err := os.Stat("/dev/virtio-ports/org.linuxcontainers.incus")
 	if err == nil {
-		ttyDevs = append(ttyDevs, "/dev/ttyS0")
+		devs = append(devs, "/dev/ttyS0")
 	}
 
 	// Add any additional user-provided console devices.
 	for _, console := range s.System.Kernel.Config.Console {
-		if !slices.Contains(ttyDevs, console.Device) {
-			ttyDevs = append(ttyDevs, console.Device)
+		if !slices.Contains(devs, console.Device) {
+			devs = append(devs, console.Device)
 		}
 	}
 
-	// Get information about the system's resources. Since we only display CPU
-	// and RAM, caching the results at creation time should be sufficient.
-	singletonTUI.systemResources, err = resources.GetResources()
-	if err != nil {
-		return nil, err
+	return devs
+}
+
+// parseTtyDevs parses a comma separated list of console devices, ignoring empty and duplicate entries.
+func parseTtyDevs(value string) []string {
+	devs := []string{}
+
+	for dev := range strings.SplitSeq(value, ",") {
+		dev = strings.TrimSpace(dev)
+		if dev == "" || slices.Contains(devs, dev) {
+			continue
+		}
+
+		devs = append(devs, dev)
 	}
 
-	// Attempt to open the system's consoles.
+	return devs
+}
+
+// newConsoleScreen returns a screen bound to the system's consoles.
+func newConsoleScreen() (tcell.Screen, error) {
 	ttys, err := newTtyMultiplexer(ttyDevs...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Construct a screen that is bound to the system console.
-	singletonTUI.screen, err = tcell.NewTerminfoScreenFromTty(ttys)
-	if err != nil {
-		return nil, err
+	return tcell.NewTerminfoScreenFromTty(ttys)
+}
+
+// ConsoleError returns the reason no console could be used, or nil if the TUI is displayed on one.
+func (t *TUI) ConsoleError() error {
+	return t.consoleErr
+}
+
+// newTUI returns a new TUI application drawing to the provided screen. This doesn't touch any
+// console device, so tests can provide a simulation screen.
+func newTUI(s *state.State, screen tcell.Screen, serialConsole bool) *TUI {
+	t := &TUI{
+		state:          s,
+		screen:         screen,
+		config:         loadConfig(serialConsole),
+		serialConsole:  serialConsole,
+		stdout:         os.Stdout,
+		footerSections: map[string]footerSection{},
+		redrawRequests: make(chan struct{}, 1),
+	}
+
+	t.logs = newLogBuffer(t.config.logLines)
+	t.theme = getTheme(t.config.theme)
+
+	// Serial consoles and limited terminals may not render box-drawing characters. This applies
+	// to all borders, including those of the frame, log view and modals.
+	if serialConsole || !canDisplayBorders(screen) {
+		useASCIIBorders()
 	}
+	t.consoleClear.Store(true)
+	t.autoScroll.Store(true)
+	t.showMACs.Store(t.config.showMACs)
+	t.showSerial.Store(t.config.showSerial)
+	t.dimLogs.Store(t.config.dimLogs)
+	t.lastWrite.Store(time.Now().UnixNano())
+
+	// Coalesce bursts of log entries into a single draw.
+	t.logDraw = newThrottle(logDrawInterval, func() {
+		t.app.Draw()
+	})
 
 	// Define a text view to show recent log entries.
-	singletonTUI.textView = tview.NewTextView().
+	t.textView = tview.NewTextView().
 		SetDynamicColors(true).
-		SetScrollable(false).
+		SetScrollable(true).
+		SetRegions(true).
+		SetMaxLines(t.config.logLines).
+		SetWrap(true).
 		SetWordWrap(true).
 		SetChangedFunc(func() {
-			singletonTUI.app.Draw()
+			t.logDraw.Request()
 		})
-	singletonTUI.textView.SetBorder(true)
+	t.textView.SetBorder(true).SetBorderColor(t.theme.borderColor)
+
+	// Define a text view to show the system status when using the dashboard layout.
+	t.statusView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	t.statusView.SetBorder(true).SetBorderColor(t.theme.borderColor).SetTitle(" System status ")
+
+	// Define a text view to show the network and applications views.
+	t.detailView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	t.detailView.SetBorder(true).SetBorderColor(t.theme.borderColor)
 
 	// Define a frame to hold the TUI's primary content.
-	singletonTUI.frame = tview.NewFrame(nil).SetBorders(0, 0, 1, 1, 0, 0)
+	t.frame = tview.NewFrame(nil).SetBorders(0, 0, 1, 1, 0, 0)
 
 	// Define a set of pages so we can present modal popups.
-	singletonTUI.pages = tview.NewPages().AddPage("frame", singletonTUI.frame, true, true)
+	t.pages = tview.NewPages().AddPage("frame", t.frame, true, true)
 
 	// Define the TUI application.
-	singletonTUI.app = tview.NewApplication().SetScreen(singletonTUI.screen).SetRoot(singletonTUI.pages, true)
+	t.app = tview.NewApplication().SetScreen(t.screen).SetRoot(t.pages, true).SetInputCapture(t.handleInput)
+	t.app.SetBeforeDrawFunc(t.handleResize)
+	t.app.SetAfterDrawFunc(t.drawClock)
+
+	// The screen is initialized by SetScreen, so its color support is now known.
+	t.noColor = os.Getenv("NO_COLOR") != "" || t.screen.Colors() < 8
+
+	// Redraw as soon as the state changes, rather than waiting for the next refresh.
+	s.OnSave(func(s *state.State) {
+		// Dismiss any enrollment QR code once the system has been registered.
+		if s.System.Provider.State.Registered && t.qrCodeShown.CompareAndSwap(true, false) {
+			t.queueUpdate(t.closeDialog)
+		}
 
-	return singletonTUI, nil
+		t.requestRedraw()
+	})
+
+	return t
 }
 
 // Write implements the Writer interface, so we can be passed to slog.NewTextHandler()
 // to update both the TUI and stdout with log entries.
 func (t *TUI) Write(p []byte) (int, error) {
+	// Ensure each record is written atomically to all destinations.
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+
+	t.lastWrite.Store(time.Now().UnixNano())
+
 	s := string(p)
 
-	num, err := fmt.Fprint(t.textView, s)
-	if err != nil {
-		return num, err
+	for line := range strings.Lines(s) {
+		err := t.appendLogLine(line)
+		if err != nil {
+			return 0, err
+		}
 	}
 
 	// Strip out coloring tags before writing to stdout for the journal.
-	s = strings.ReplaceAll(s, "[blue]", "")
-	s = strings.ReplaceAll(s, "[green]", "")
-	s = strings.ReplaceAll(s, "[yellow]", "")
-	s = strings.ReplaceAll(s, "[purple]", "")
-	s = strings.ReplaceAll(s, "[red]", "")
-	s = strings.ReplaceAll(s, "[white]", "")
+	plain := stripColorTags(s)
+
+	num, err := fmt.Fprint(t.stdout, plain)
+
+	// Mirror to the log file, if enabled. Failures must never prevent logging to the console.
+	if t.logFile != nil {
+		fileErr := t.logFile.Write([]byte(plain))
+		if fileErr != nil {
+			_ = t.appendLogLine(time.Now().Format(time.DateTime) + " [yellow]WARN[white] Unable to write to log file, only keeping logs in memory until it can be written again[purple] err=" + fileErr.Error() + "[white]\n")
+		}
+	}
 
-	return fmt.Fprint(os.Stdout, s)
+	return num, err
+}
+
+// prefillLogs seeds the log view with the most recent journal entries of the daemon, along
+// with any notable kernel messages.
+func (t *TUI) prefillLogs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count := min(t.config.logLines, journalPrefillEntries)
+
+	lines, err := systemd.GetRecentJournalEntries(ctx, "incus-osd.service", count)
+	if err != nil {
+		return
+	}
+
+	entries := make([]logEntry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, parseLogEntry(colorizePlainLogLine(line)+"\n"))
+	}
+
+	messages, err := systemd.GetRecentKernelMessages(ctx, count)
+	if err == nil {
+		entries = mergeKernelMessages(entries, messages)
+	}
+
+	for _, entry := range entries {
+		_ = t.appendLogEntry(entry)
+	}
 }
 
-// Run is a wrapper to start the underlying TUI application.
-func (t *TUI) Run() error {
+// mergeKernelMessages adds kernel messages to the provided log entries, ordered by time. Entries
+// without a timestamp of their own, such as those logged by systemd, stay after the prior entry.
+func mergeKernelMessages(entries []logEntry, messages []systemd.KernelMessage) []logEntry {
+	type timedEntry struct {
+		timestamp string
+		entry     logEntry
+	}
+
+	timed := make([]timedEntry, 0, len(entries)+len(messages))
+
+	timestamp := ""
+
+	for _, entry := range entries {
+		text := stripColorTags(entry.text)
+		if len(text) >= len(time.DateTime) {
+			_, err := time.ParseInLocation(time.DateTime, text[:len(time.DateTime)], time.Local)
+			if err == nil {
+				timestamp = text[:len(time.DateTime)]
+			}
+		}
+
+		timed = append(timed, timedEntry{timestamp: timestamp, entry: entry})
+	}
+
+	for _, message := range messages {
+		level := slog.LevelInfo
+
+		switch {
+		case message.Priority <= 3:
+			level = slog.LevelError
+		case message.Priority == 4:
+			level = slog.LevelWarn
+		default:
+		}
+
+		entry := parseLogEntry(message.Time.Local().Format(time.DateTime) + " " + getLevelColor(level) + level.String() + "[white] " + tview.Escape(message.Message) + "\n")
+		entry.source = "kernel"
+
+		timed = append(timed, timedEntry{timestamp: message.Time.Local().Format(time.DateTime), entry: entry})
+	}
+
+	slices.SortStableFunc(timed, func(a timedEntry, b timedEntry) int {
+		return strings.Compare(a.timestamp, b.timestamp)
+	})
+
+	ret := make([]logEntry, 0, len(timed))
+	for _, te := range timed {
+		ret = append(ret, te.entry)
+	}
+
+	return ret
+}
+
+// SetLogFile enables mirroring of all log entries to the provided file.
+func (t *TUI) SetLogFile(path string) {
+	t.logFile = newLogFile(path)
+}
+
+// appendLogLine retains a single log line, displaying it if it matches the current filters.
+func (t *TUI) appendLogLine(line string) error {
+	return t.appendLogEntry(parseLogEntry(line))
+}
+
+// appendLogEntry retains a single log entry, displaying it if it matches the current filters.
+func (t *TUI) appendLogEntry(entry logEntry) error {
+	t.logs.Append(entry)
+
+	switch {
+	case entry.level >= slog.LevelError:
+		t.errorCount.Add(1)
+	case entry.level >= slog.LevelWarn:
+		t.warningCount.Add(1)
+	default:
+	}
+
+	t.logMutex.Lock()
+
+	if !t.matchesFilter(entry) {
+		t.logMutex.Unlock()
+
+		return nil
+	}
+
+	line := t.colorize(t.theme.colorizeLogLine(t.highlightMatches(entry.text)))
+
+	paused := !t.autoScroll.Load()
+	if paused {
+		t.pausedLines++
+	}
+
+	t.logMutex.Unlock()
+
+	_, err := fmt.Fprint(t.textView, line)
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		t.updateScrollIndicator()
+	}
+
+	return nil
+}
+
+// Run is a wrapper to start the underlying TUI application. The application is stopped once
+// the provided context is cancelled.
+func (t *TUI) Run(ctx context.Context) error {
 	// Setup a gofunc to cycle through modal dialogs, one per second.
 	go func() {
 		for i := 0; ; i++ {
@@ -158,63 +493,159 @@ func (t *TUI) Run() error {
 			case numModals > 1:
 				// Cycle through each of the current modals.
 				modalIndex := i % numModals
-				t.renderModal(fmt.Sprintf("[%d/%d] %s", modalIndex+1, numModals, t.modalMessages[modalIndex].title), t.modalMessages[modalIndex].message, t.modalMessages[modalIndex].progress)
+				t.renderModal(fmt.Sprintf("[%d/%d] %s", modalIndex+1, numModals, t.modalMessages[modalIndex].title), t.modalMessages[modalIndex])
 			case numModals == 1:
 				// No point in re-drawing anything when there's only one modal and no modals were removed.
 				// Any updates to the modal will have already been drawn in `quickDraw()`.
 				if numPriorModals > 1 {
-					t.renderModal(t.modalMessages[0].title, t.modalMessages[0].message, t.modalMessages[0].progress)
+					t.renderModal(t.modalMessages[0].title, t.modalMessages[0])
 				}
 			default:
 				// No modal to display.
-				t.pages.RemovePage("modal")
+				t.showModalPage(nil)
+				t.currentModal = nil
 			}
 
 			t.modalMutex.Unlock()
 
-			time.Sleep(1 * time.Second)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(1 * time.Second):
+			}
 		}
 	}()
 
-	// Setup a gofunc to periodically re-draw the entire screen.
+	// Setup a gofunc to periodically re-draw the entire screen, as well as whenever requested.
 	go func() {
-		for i := 0; ; i++ {
+		nextClear := time.Now().Add(t.config.redrawInterval)
+
+		tick := time.NewTimer(0)
+		defer tick.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.redrawRequests:
+				t.redrawScreen()
+
+				continue
+			case <-tick.C:
+			}
+
 			// When the daemon starts up, several log messages from systemd are
-			// also written to the console. Once a minute forcefully clear the
+			// also written to the console. Periodically forcefully clear the
 			// entire console prior to drawing the TUI.
-			if i%12 == 1 {
+			if t.consoleClear.Load() && t.config.clearInterval > 0 && !time.Now().Before(nextClear) {
 				// Send "ESC c" sequence to each console device.
 				for _, dev := range ttyDevs {
 					_ = os.WriteFile(dev, []byte{0x1B, 0x63}, 0o600)
 				}
+
+				nextClear = time.Now().Add(t.config.clearInterval)
 			}
 
+			if t.config.showSparkline {
+				t.cpuUsage.Sample()
+			}
+
+			t.thermal = getThermalStatus(t.thermal)
+
+			t.refreshDimmedLogs()
 			t.redrawScreen()
-			time.Sleep(5 * time.Second)
+
+			// Keep the machine-readable status in sync with the console once installed.
+			if !t.state.ShouldPerformInstall {
+				t.writeStatusSnapshot()
+			}
+
+			// Follow the boot progress more closely until the daemon is ready.
+			interval := t.config.redrawInterval
+			if t.startingUp() {
+				interval = min(interval, startupRedrawInterval)
+			}
+
+			tick.Reset(interval)
 		}
 	}()
 
+	// Setup a gofunc to alert about any failed systemd units.
+	go t.watchFailedUnits(ctx)
+
+	// Setup a gofunc to keep the header clock up to date between re-draws.
+	go t.tickClock(ctx)
+
+	// Setup a gofunc to periodically check connectivity.
+	go t.watchConnectivity(ctx)
+
+	// Stop the application when the context is cancelled.
+	go func() {
+		<-ctx.Done()
+		t.app.Stop()
+	}()
+
 	return t.app.Run()
 }
 
+// SetConsoleClear controls whether the console is periodically cleared. This is enabled by
+// default to get rid of any messages written to the console during early boot.
+func (t *TUI) SetConsoleClear(enabled bool) {
+	t.consoleClear.Store(enabled)
+}
+
 // AddModal adds a new modal popup to display to the user.
 func (t *TUI) AddModal(title string, category string) *Modal {
-	ret := &Modal{
+	return t.addModal(&Modal{
 		title:    title,
 		category: category,
 		isDone:   false,
-		t:        t,
-	}
+	})
+}
+
+// addModal queues the provided modal for display, once fully set up.
+func (t *TUI) addModal(m *Modal) *Modal {
+	m.t = t
 
 	t.modalMutex.Lock()
-	t.modalMessages = append(t.modalMessages, ret)
+	t.modalMessages = append(t.modalMessages, m)
 	t.modalMutex.Unlock()
 
-	return ret
+	return m
+}
+
+// DisplayBusyModal adds a modal dialog with an animated spinner, indicating that an operation of
+// unknown duration is in progress. The spinner stops once the returned modal is done.
+func (t *TUI) DisplayBusyModal(title string, msg string) *Modal {
+	m := t.addModal(&Modal{
+		title:    title,
+		category: title,
+		busy:     true,
+	})
+	m.Update(msg)
+
+	go m.animateSpinner()
+
+	return m
+}
+
+// SetStatusBanner displays a prominent banner in the header until cleared by setting an empty text.
+// This is meant to flag disruptive operations, such as an OS update, during which the system
+// shouldn't be powered off.
+func (t *TUI) SetStatusBanner(text string, color tcell.Color) {
+	t.statusBannerMutex.Lock()
+	t.statusBanner = tview.Escape(text)
+	t.statusBannerColor = color
+	t.statusBannerMutex.Unlock()
+
+	t.requestRedraw()
 }
 
 // GetModal returns an existing modal with the specified category, and nil if it doesn't exist.
 func (t *TUI) GetModal(category string) *Modal {
+	t.modalMutex.Lock()
+	defer t.modalMutex.Unlock()
+
 	for _, m := range t.modalMessages {
 		if m.category == category {
 			return m
@@ -232,30 +663,44 @@ func (t *TUI) quickDraw() {
 
 	if len(t.modalMessages) == 1 {
 		if !t.modalMessages[0].isDone {
-			t.renderModal(t.modalMessages[0].title, t.modalMessages[0].message, t.modalMessages[0].progress)
+			t.renderModal(t.modalMessages[0].title, t.modalMessages[0])
 		} else {
-			t.pages.RemovePage("modal")
+			t.showModalPage(nil)
+			t.currentModal = nil
 		}
 	}
 
 	t.modalMutex.Unlock()
 }
 
-// renderModal displays a centered popup dialog. Optionally, if progress is greater than zero,
-// renders a progress bar at the bottom.
-func (t *TUI) renderModal(title string, msg string, progress float64) {
-	// Returns a new primitive which puts the provided primitive in the center and
-	// sets its size to the given width and height.
-	modal := func(p tview.Primitive, width, height int) tview.Primitive {
-		return tview.NewFlex().
-			AddItem(nil, 0, 1, false).
-			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-				AddItem(nil, 0, 1, false).
-				AddItem(p, height, 1, true).
-				AddItem(nil, 0, 1, false), width, 1, true).
-			AddItem(nil, 0, 1, false)
+// handleResize triggers an immediate re-draw of the screen and any modal whenever the console size changes.
+func (t *TUI) handleResize(screen tcell.Screen) bool {
+	width, height := screen.Size()
+	if width == t.width && height == t.height {
+		return false
 	}
 
+	resized := t.width != 0 || t.height != 0
+	t.width = width
+	t.height = height
+
+	if resized {
+		t.requestRedraw()
+
+		// When multiple modals are displayed, they get re-rendered every second anyway.
+		t.modalMutex.Lock()
+		if len(t.modalMessages) == 1 {
+			t.renderModal(t.modalMessages[0].title, t.modalMessages[0])
+		}
+		t.modalMutex.Unlock()
+	}
+
+	return false
+}
+
+// renderModal displays a centered popup dialog. Optionally, if progress is greater than zero,
+// renders a progress bar at the bottom, along with any transfer details, or a spinner for busy modals.
+func (t *TUI) renderModal(title string, m *Modal) {
 	// Calculate width and height for modal dialog.
 	consoleWidth, consoleHeight := t.screen.Size()
 	modalWidth := consoleWidth * 3 / 4
@@ -263,9 +708,9 @@ func (t *TUI) renderModal(title string, msg string, progress float64) {
 
 	// Setup a text view to display the message.
 	textView := tview.NewTextView().
-		SetText(msg).
+		SetText(t.colorize(m.message)).
 		SetDynamicColors(true).
-		SetScrollable(false).
+		SetScrollable(true).
 		SetWordWrap(true)
 
 	// Setup a grid to show the text area and possibly a progress bar.
@@ -276,88 +721,355 @@ func (t *TUI) renderModal(title string, msg string, progress float64) {
 		AddItem(textView, 0, 0, 1, 1, 0, 0, false)
 
 	// If a maximum value is provided, display the progress bar.
-	if progress > 0 {
-		progressBar := NewProgressBar()
+	rows := []int{modalHeight - 4}
+
+	if m.progress > 0 {
+		progressBar := t.newProgressBar()
+		progressBar.SetMax(100)
+		progressBar.SetProgress(int64(m.progress * 100))
+
+		// Transfer details already include the percentage.
+		progressBar.SetShowPercent(m.details == "")
+
+		rows = append(rows, 1)
+		grid.AddItem(progressBar, len(rows)-1, 0, 1, 1, 0, 0, false)
+	}
+
+	// Otherwise, show a spinner for operations of unknown duration.
+	if m.busy && m.progress <= 0 {
+		spinner := t.newSpinner()
+		spinner.SetFrame(m.spinnerFrame)
+
+		rows = append(rows, 1)
+		grid.AddItem(spinner, len(rows)-1, 0, 1, 1, 0, 0, false)
+	}
+
+	// Display any additional labeled progress bars, greying out completed ones.
+	labelWidth := 0
+	for _, bar := range m.bars {
+		labelWidth = max(labelWidth, min(tview.TaggedStringWidth(bar.label)+1, modalWidth/3))
+	}
+
+	for _, bar := range m.bars {
+		progressBar := t.newProgressBar()
 		progressBar.SetMax(100)
-		progressBar.SetProgress(int64(progress * 100))
-		grid.SetRows(modalHeight-6, 1).AddItem(progressBar, 1, 0, 1, 1, 0, 0, false)
+		progressBar.SetProgress(int64(bar.progress * 100))
+
+		if bar.done {
+			progressBar.SetFilledColor(tcell.ColorGray)
+		}
+
+		label := tview.NewTextView().SetText(bar.label)
+		if bar.done {
+			label.SetTextColor(tcell.ColorGray)
+		}
+
+		row := tview.NewFlex().
+			AddItem(label, labelWidth, 0, false).
+			AddItem(progressBar, 0, 1, false)
+
+		rows = append(rows, 1)
+		grid.AddItem(row, len(rows)-1, 0, 1, 1, 0, 0, false)
 	}
 
-	grid.SetTitle(" " + title + " ").SetBorder(true)
+	// Show the transfer details, if any, below the progress bar.
+	if m.details != "" {
+		details := tview.NewTextView().
+			SetText(m.details).
+			SetTextAlign(tview.AlignCenter)
+
+		rows = append(rows, 1)
+		grid.AddItem(details, len(rows)-1, 0, 1, 1, 0, 0, false)
+	}
+
+	// Each additional row also takes a line for its border.
+	rows[0] -= 2 * (len(rows) - 1)
+	grid.SetRows(rows...)
+
+	// Let long messages be scrolled, keeping any progress bars pinned below the text.
+	m.scrollPage = max(rows[0], 1)
+	m.scrollMax = max(countWrappedLines(t.colorize(m.message), modalWidth-4)-rows[0], 0)
+	m.scrollOffset = min(m.scrollOffset, m.scrollMax)
+	textView.ScrollTo(m.scrollOffset, 0)
 
-	t.pages.AddPage("modal", modal(grid, modalWidth, modalHeight), true, true)
-	t.app.Draw()
+	t.currentModal = m
+	t.currentModalTitle = title
+
+	if m.scrollOffset < m.scrollMax {
+		more := "more ↓"
+		if t.serialConsole {
+			more = "more v"
+		}
+
+		title += " (" + more + ")"
+	}
+
+	grid.SetBordersColor(t.theme.borderColor)
+	grid.SetTitle(" " + title + " ").SetBorder(true).SetBorderColor(t.theme.borderColor)
+
+	t.showModalPage(centered(grid, modalWidth, modalHeight))
+}
+
+// showModalPage sets the page displaying the current modal, or removes it if nil. The page is
+// applied on the TUI goroutine without waiting, only keeping the latest one if several are set
+// before it runs. The caller must hold modalMutex.
+func (t *TUI) showModalPage(p tview.Primitive) {
+	if p == nil && t.modalPage == nil {
+		return
+	}
+
+	t.modalPage = p
+
+	if t.modalPagePending {
+		return
+	}
+
+	t.modalPagePending = true
+
+	t.queueUpdate(func() {
+		t.modalMutex.Lock()
+		page := t.modalPage
+		t.modalPagePending = false
+		t.modalMutex.Unlock()
+
+		if page == nil {
+			t.pages.RemovePage("modal")
+
+			return
+		}
+
+		t.pages.AddPage("modal", page, true, true)
+
+		// Keep any interactive dialog on top so it retains focus.
+		if t.pages.HasPage("dialog") {
+			t.pages.SendToFront("dialog")
+			t.app.SetFocus(t.pages)
+		}
+	})
+}
+
+// screenContent holds everything needed to re-draw the TUI frame which is gathered outside of the
+// TUI goroutine, as it may involve running commands.
+type screenContent struct {
+	hostname        string
+	thermal         string
+	scrollIndicator string
+	lines           []statusLine
+
+	// The primitive shown as the main content, along with its title and text when not the log view.
+	view  *tview.TextView
+	title string
+	text  string
+	hint  string
+}
+
+// requestRedraw asks the Run loop to re-draw the screen, coalescing concurrent requests. It's safe
+// to call from any goroutine, including TUI event handlers.
+func (t *TUI) requestRedraw() {
+	select {
+	case t.redrawRequests <- struct{}{}:
+	default:
+	}
+}
+
+// queueUpdate runs the function on the TUI goroutine and then draws the screen, without waiting
+// for it. Unlike QueueUpdateDraw, it's safe to call from TUI event handlers.
+func (t *TUI) queueUpdate(f func()) {
+	go t.app.QueueUpdateDraw(f)
 }
 
 // redrawScreen clears and completely re-draws the TUI frame. This is necessary when updating
-// header or footer values, such as showing the current time.
+// header or footer values, such as showing the current time. It waits for the frame to be drawn,
+// so must not be called from the TUI goroutine; use requestRedraw instead.
 func (t *TUI) redrawScreen() {
 	if t.frame == nil {
 		return
 	}
 
+	content := t.gatherScreenContent()
+
+	t.app.QueueUpdateDraw(func() {
+		t.drawScreen(content)
+	})
+}
+
+// gatherScreenContent collects the header, footer and main content of the TUI frame.
+func (t *TUI) gatherScreenContent() screenContent {
+	content := screenContent{}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = tview.Escape("[unknown]")
+	}
+
+	content.hostname = hostname
+	content.thermal = t.thermal.String()
+	content.scrollIndicator = t.getScrollIndicator()
+	content.lines = t.getStatusLines()
+
+	// Only the log view is available during install.
+	if t.state.ShouldPerformInstall {
+		t.activeView.Store(viewLogs)
+	}
+
+	switch {
+	case t.activeView.Load() == viewNetwork:
+		content.view = t.detailView
+		content.title = " Network "
+		content.text = t.renderNetworkView()
+	case t.activeView.Load() == viewApplications:
+		text, err := t.renderApplicationsView()
+		if err != nil {
+			text = colorTag(t.theme.errorColor) + "Unable to list applications: " + tview.Escape(err.Error()) + "[white]"
+		}
+
+		content.view = t.detailView
+		content.title = " Applications "
+		content.text = text
+	case t.activeView.Load() == viewUnits:
+		text, err := t.renderUnitsView()
+		if err != nil {
+			text = colorTag(t.theme.errorColor) + "Unable to list systemd units: " + tview.Escape(err.Error()) + "[white]"
+		}
+
+		content.view = t.detailView
+		content.title = " Units "
+		content.text = text
+	case t.activeView.Load() == viewEvents:
+		content.view = t.detailView
+		content.title = " Events "
+		content.text = t.renderEventsView()
+	case t.activeView.Load() == viewStorage:
+		text, err := t.renderStorageView()
+		if err != nil {
+			text = colorTag(t.theme.errorColor) + "Unable to list block devices: " + tview.Escape(err.Error()) + "[white]"
+		}
+
+		content.view = t.detailView
+		content.title = " Storage "
+		content.text = text
+	case !t.showLogs.Load() && t.startingUp():
+		// Show the boot progress until the daemon is ready, rather than the raw early boot logs.
+		content.view = t.statusView
+		content.text = t.renderStartupView()
+		content.hint = "Press F4 to toggle the log view"
+	case t.config.layout == layoutDashboard && !t.showLogs.Load():
+		// Render the status on its own page, with only a hint in the footer.
+		content.view = t.statusView
+		content.text = renderStatusLines(content.lines, t.theme.labelColor)
+		content.hint = "Press F4 to toggle the log view"
+	default:
+	}
+
+	if content.view == t.detailView {
+		content.hint = "Press Tab to switch views"
+	}
+
+	return content
+}
+
+// drawScreen re-populates the TUI frame with the gathered content. It must run on the TUI goroutine.
+func (t *TUI) drawScreen(content screenContent) {
 	t.frame.Clear()
 
+	t.textView.SetTitle(content.scrollIndicator)
+
 	// Display header.
-	hostname, err := os.Hostname()
-	if err == nil {
-		t.frame.AddText(hostname, true, tview.AlignLeft, tcell.ColorWhite)
+	t.frame.AddText(content.hostname, true, tview.AlignLeft, t.theme.headerColor)
+
+	activity, activityColor := t.getActivityIndicator()
+	t.frame.AddText(activity, true, tview.AlignLeft, activityColor)
+
+	t.frame.AddText(t.state.OS.Name+" "+t.state.OS.RunningRelease, true, tview.AlignCenter, t.theme.headerColor)
+
+	if !t.state.ShouldPerformInstall {
+		t.frame.AddText(t.getTabBar(), true, tview.AlignCenter, t.theme.headerColor)
+	}
+
+	t.frame.AddText(t.getClockPlaceholder(), true, tview.AlignRight, t.theme.headerColor)
+
+	// Flag any warnings or errors logged since boot.
+	counts := t.getLogCounts()
+	if counts != "" {
+		t.frame.AddText(t.colorize(counts), true, tview.AlignRight, t.theme.headerColor)
 	}
 
-	t.frame.AddText(t.state.OS.Name+" "+t.state.OS.RunningRelease, true, tview.AlignCenter, tcell.ColorWhite)
-	t.frame.AddText(time.Now().Format("2006-01-02 15:04 MST"), true, tview.AlignRight, tcell.ColorWhite)
+	// Indicate if the log view is being filtered.
+	filter := t.getFilterDescription()
+	if filter != "" {
+		t.frame.AddText(tview.Escape("[filter: "+filter+"]"), true, tview.AlignRight, t.theme.warningColor)
+	}
+
+	search := t.getSearchDescription()
+	if search != "" {
+		t.frame.AddText(tview.Escape("["+search+"]"), true, tview.AlignRight, t.theme.warningColor)
+	}
+
+	// Display any banner set while a disruptive operation is in progress.
+	t.statusBannerMutex.Lock()
+
+	if t.statusBanner != "" {
+		t.frame.AddText(t.statusBanner, true, tview.AlignCenter, t.statusBannerColor)
+	}
+
+	t.statusBannerMutex.Unlock()
+
+	// Display a warning if the system is running hot or being throttled.
+	if content.thermal != "" {
+		t.frame.AddText(content.thermal, true, tview.AlignCenter, t.theme.errorColor)
+	}
+
+	// Display a persistent indicator until security events have been acknowledged.
+	numSecurityEvents := t.getUnacknowledgedSecurityEvents()
+	if numSecurityEvents > 0 {
+		t.frame.AddText(fmt.Sprintf("WARNING: %d unacknowledged security event(s), press F6 to review", numSecurityEvents), true, tview.AlignCenter, t.theme.errorColor)
+	}
 
 	// Don't display degraded security warnings or footer during install.
 	if !t.state.ShouldPerformInstall {
 		if t.state.UsingSWTPM {
-			t.frame.AddText("WARNING: Degraded security state: no physical TPM found, using swtpm", true, tview.AlignCenter, tcell.ColorRed)
+			t.frame.AddText("WARNING: Degraded security state: no physical TPM found, using swtpm", true, tview.AlignCenter, t.theme.errorColor)
 		}
 
 		if t.state.SecureBootDisabled {
-			t.frame.AddText("WARNING: Degraded security state: Secure Boot is disabled", true, tview.AlignCenter, tcell.ColorRed)
+			t.frame.AddText("WARNING: Degraded security state: Secure Boot is disabled", true, tview.AlignCenter, t.theme.errorColor)
 		}
 
 		if t.state.FullAgentEnabled {
-			t.frame.AddText("WARNING: Degraded security state: incus-agent has been fully enabled", true, tview.AlignCenter, tcell.ColorRed)
+			t.frame.AddText("WARNING: Degraded security state: incus-agent has been fully enabled", true, tview.AlignCenter, t.theme.errorColor)
 		}
+	}
 
-		// Get list of applications from state.
-		apps, err := applications.GetInstalled(context.Background(), t.state)
-		if err != nil {
-			return
+	// Show main content.
+	if content.view != nil {
+		if content.title != "" {
+			content.view.SetTitle(content.title)
 		}
 
-		appStatus := []string{}
-		for _, app := range apps {
-			appStatus = append(appStatus, app.Name()+"("+app.FriendlyVersion()+")")
-		}
+		content.view.SetText(t.colorize(content.text))
+		t.frame.SetPrimitive(content.view)
+		t.frame.AddText(t.translate(content.hint), false, tview.AlignLeft, tcell.ColorWhite)
 
-		slices.Sort(appStatus)
+		return
+	}
 
-		consoleWidth, _ := t.screen.Size()
-		for _, line := range wrapFooterText("Network configuration", strings.Join(t.getIPAddresses(), ", "), consoleWidth) {
-			t.frame.AddText(line, false, tview.AlignLeft, tcell.ColorWhite)
-		}
+	consoleWidth, _ := t.screen.Size()
 
-		for _, line := range wrapFooterText("Machine", getMachineInfo(t.systemResources), consoleWidth) {
-			t.frame.AddText(line, false, tview.AlignLeft, tcell.ColorWhite)
-		}
+	for _, line := range content.lines {
+		if line.label == "" {
+			t.frame.AddText(line.text, false, tview.AlignLeft, line.color)
 
-		for _, line := range wrapFooterText("Installed application(s)", strings.Join(appStatus, ", "), consoleWidth) {
-			t.frame.AddText(line, false, tview.AlignLeft, tcell.ColorWhite)
+			continue
 		}
 
-		if !t.state.System.Security.State.EncryptionRecoveryKeysRetrieved {
-			t.frame.AddText("WARNING: Some encryption recovery keys have not been retrieved yet!", false, tview.AlignLeft, tcell.ColorRed)
+		for _, wrapped := range wrapFooterText(line.label, line.text, consoleWidth, t.theme.labelColor) {
+			t.frame.AddText(t.colorize(wrapped), false, tview.AlignLeft, tcell.ColorWhite)
 		}
 	}
 
-	// Show main content.
 	if t.textView != nil {
 		t.frame.SetPrimitive(t.textView)
 	}
-
-	t.app.Draw()
 }
 
 // Return a list of IP addresses for configured interfaces.
@@ -369,15 +1081,20 @@ func (t *TUI) getIPAddresses() []string {
 	ret := []string{}
 
 	appendIPs := func(name string) {
-		// Handle missing interfaces.
-		_, err := os.Stat(filepath.Join("/sys/class/net/", name))
-		if err != nil && errors.Is(err, os.ErrNotExist) {
+		// Skip missing or disabled interfaces.
+		iface, err := net.InterfaceByName(name)
+		if err != nil || iface.Flags&net.FlagUp == 0 {
 			return
 		}
 
-		addrs, err := systemd.GetIPAddresses(context.Background(), name)
+		addrs, err := systemd.GetFilteredIPAddresses(context.Background(), name, t.config.ipFilter)
 		if err == nil {
-			ret = append(ret, name+"("+strings.Join(addrs, ", ")+")")
+			annotations := sortIPAddresses(addrs)
+			if t.showMACs.Load() && len(iface.HardwareAddr) > 0 {
+				annotations = append([]string{iface.HardwareAddr.String()}, annotations...)
+			}
+
+			ret = append(ret, name+"("+strings.Join(annotations, ", ")+")")
 		}
 	}
 
@@ -399,26 +1116,68 @@ func (t *TUI) getIPAddresses() []string {
 		}
 	}
 
-	return ret
+	slices.Sort(ret)
+
+	return slices.Compact(ret)
 }
 
-// Performs a very basic text wrapping at a given maximum length, only on spaces. Returns a
-// reversed array, since that is how the frame's footer logic expects things.
-func wrapFooterText(label string, text string, maxLineLength int) []string {
+// sortIPAddresses sorts IPv4 addresses before IPv6 ones, removing any duplicates.
+func sortIPAddresses(addrs []string) []string {
+	ret := slices.Clone(addrs)
+
+	slices.SortFunc(ret, func(a string, b string) int {
+		addrA, errA := netip.ParseAddr(a)
+		addrB, errB := netip.ParseAddr(b)
+
+		if errA != nil || errB != nil {
+			return strings.Compare(a, b)
+		}
+
+		return addrA.Compare(addrB)
+	})
+
+	return slices.Compact(ret)
+}
+
+// Performs a very basic text wrapping at a given maximum length, only on spaces. Continuation
+// lines are indented to align with the text following the label. Returns a reversed array,
+// since that is how the frame's footer logic expects things.
+func wrapFooterText(label string, text string, maxLineLength int, labelColor tcell.Color) []string {
 	ret := []string{}
 
-	currentLine := "[green]" + label + ":[white] "
-	currentLen := len(label) + 2
+	// Only count the displayed width, ignoring any color tags.
+	currentLine := colorTag(labelColor) + label + ":[white] "
+	currentLen := tview.TaggedStringWidth(label) + 2
+
+	// Don't bother indenting if it would take up most of the line.
+	indent := currentLen
+	if indent > maxLineLength/2 {
+		indent = 0
+	}
 
 	for word := range strings.SplitSeq(text, " ") {
-		if currentLen+len(word) > maxLineLength {
+		wordLen := tview.TaggedStringWidth(word)
+
+		// Hard-split any word which can't fit on a line of its own.
+		for wordLen > maxLineLength-indent && currentLen+wordLen > maxLineLength {
+			head, tail := splitAtWidth(word, maxLineLength-currentLen)
+
+			ret = append(ret, currentLine+head)
+			currentLine = strings.Repeat(" ", indent)
+			currentLen = indent
+
+			word = tail
+			wordLen = tview.TaggedStringWidth(word)
+		}
+
+		if currentLen+wordLen > maxLineLength {
 			ret = append(ret, currentLine)
-			currentLine = ""
-			currentLen = 0
+			currentLine = strings.Repeat(" ", indent)
+			currentLen = indent
 		}
 
 		currentLine += word + " "
-		currentLen += len(word) + 1
+		currentLen += wordLen + 1
 	}
 
 	if len(currentLine) > 0 {
@@ -473,3 +1232,18 @@ func getMachineInfo(r *api.Resources) string {
 	// Return a string like "AMD EPYC 7763 64-Core Processor (numa=8, sockets=2, cores=128, threads=256) (x86_64) / 514GiB memory".
 	return fmt.Sprintf("%s (numa=%d, sockets=%d, cores=%d, threads=%d) (%s) / %s memory", r.CPU.Sockets[0].Name, len(r.Memory.Nodes), len(r.CPU.Sockets), numCores, r.CPU.Total, r.CPU.Architecture, units.GetByteSizeStringIEC(memory, 0))
 }
+
+// splitAtWidth splits a string so its first part fits within the given display width, always
+// keeping at least one character in the first part.
+func splitAtWidth(s string, width int) (string, string) {
+	currentWidth := 0
+
+	for i, r := range s {
+		currentWidth += tview.TaggedStringWidth(string(r))
+		if i > 0 && currentWidth > width {
+			return s[:i], s[i:]
+		}
+	}
+
+	return s, ""
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// failedUnitsInterval is how often systemd units are checked for failures.
+const failedUnitsInterval = 10 * time.Second
+
+// watchFailedUnits periodically checks for failed systemd units, showing a modal for each unit
+// which fails and removing it once the unit recovers.
+func (t *TUI) watchFailedUnits(ctx context.Context) {
+	modals := map[string]*Modal{}
+
+	for {
+		units, err := systemd.ListUnitStatuses(ctx)
+		if err == nil {
+			t.updateFailedUnitModals(units, modals)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(failedUnitsInterval):
+		}
+	}
+}
+
+// updateFailedUnitModals adds a modal for each newly failed unit and closes those of units which
+// are no longer failed. Modals are tracked by unit name in the provided map.
+func (t *TUI) updateFailedUnitModals(units []systemd.UnitStatus, modals map[string]*Modal) {
+	failed := map[string]bool{}
+
+	for _, unit := range units {
+		if unit.Active != "failed" {
+			continue
+		}
+
+		failed[unit.Name] = true
+
+		_, ok := modals[unit.Name]
+		if ok {
+			continue
+		}
+
+		modal := t.AddModal("Unit failure", "failed-unit-"+unit.Name)
+		modal.Update(fmt.Sprintf("[red]%s[white] (%s) has failed (%s).\n\nIts logs can be retrieved through the /1.0/debug/log API with unit=%s, and all units are listed in the Units view.", unit.Name, unit.Description, unit.Sub, unit.Name))
+		modals[unit.Name] = modal
+	}
+
+	for name, modal := range modals {
+		if !failed[name] {
+			modal.Done()
+			delete(modals, name)
+		}
+	}
+}
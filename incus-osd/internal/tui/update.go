@@ -0,0 +1,25 @@
+package tui
+
+import "log/slog"
+
+// confirmUpdateCheck asks the user to confirm an update check, then triggers it through the daemon.
+func (t *TUI) confirmUpdateCheck() {
+	if t.state.TriggerUpdate == nil {
+		t.showMessage("Update check", "Updates can't be checked for until the system has finished starting up.")
+
+		return
+	}
+
+	confirmed, err := t.DisplayConfirm("Update check", "Are you sure you want to check for updates now?\n\nAny available update will be applied.")
+	if err != nil || !confirmed {
+		return
+	}
+
+	slog.Info("Update check requested from the console")
+
+	// An update check is already pending if the channel is full.
+	select {
+	case t.state.TriggerUpdate <- true:
+	default:
+	}
+}
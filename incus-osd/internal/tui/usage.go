@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/units"
+	"golang.org/x/sys/unix"
+)
+
+// getResourceUsage returns a compact summary of the current CPU load, memory and root filesystem usage.
+func getResourceUsage() string {
+	usage := []string{}
+
+	// Get the load average.
+	content, err := os.ReadFile("/proc/loadavg")
+	if err == nil {
+		fields := strings.Fields(string(content))
+		if len(fields) >= 3 {
+			usage = append(usage, "load "+strings.Join(fields[:3], " "))
+		}
+	}
+
+	// Get the memory usage.
+	total, available, err := getMemoryUsage()
+	if err == nil && total > 0 {
+		usage = append(usage, fmt.Sprintf("memory %s/%s", units.GetByteSizeStringIEC(total-available, 1), units.GetByteSizeStringIEC(total, 1)))
+	}
+
+	// Get the root filesystem usage.
+	var fs unix.Statfs_t
+
+	err = unix.Statfs("/", &fs)
+	if err == nil && fs.Blocks > 0 {
+		fsTotal := int64(fs.Blocks) * fs.Bsize         // #nosec G115
+		fsUsed := int64(fs.Blocks-fs.Bfree) * fs.Bsize // #nosec G115
+
+		usage = append(usage, fmt.Sprintf("disk %s/%s", units.GetByteSizeStringIEC(fsUsed, 1), units.GetByteSizeStringIEC(fsTotal, 1)))
+	}
+
+	return strings.Join(usage, ", ")
+}
+
+// getMemoryUsage returns the total and available memory in bytes, as reported by /proc/meminfo.
+func getMemoryUsage() (int64, int64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer f.Close()
+
+	var total, available int64
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[0] {
+		case "MemTotal:":
+			total = value * 1024
+		case "MemAvailable:":
+			available = value * 1024
+		default:
+		}
+	}
+
+	return total, available, scanner.Err()
+}
+
+// getUptime returns the system uptime formatted as days, hours and minutes.
+func getUptime() (string, error) {
+	content, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return "", errors.New("invalid /proc/uptime content")
+	}
+
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", err
+	}
+
+	return formatUptime(time.Duration(uptime) * time.Second), nil
+}
+
+// formatUptime formats a duration as days, hours and minutes.
+func formatUptime(d time.Duration) string {
+	minutes := int(d.Minutes())
+
+	return fmt.Sprintf("%dd %dh %dm", minutes/(24*60), minutes/60%24, minutes%60)
+}
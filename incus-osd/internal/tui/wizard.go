@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+	apiseed "github.com/lxc/incus-os/incus-osd/api/seed"
+	"github.com/lxc/incus-os/incus-osd/internal/seed"
+)
+
+// How long the setup wizard waits for input before continuing with the default configuration.
+const setupWizardTimeout = 60 * time.Second
+
+// setupApplications lists the primary applications which can be selected in the setup wizard.
+var setupApplications = []string{"incus", "migration-manager", "operations-center"}
+
+// setupChoices holds the values entered in the first-boot setup wizard.
+type setupChoices struct {
+	hostname      string
+	network       networkEdit
+	application   string
+	incusDefaults bool
+}
+
+// RunSetupWizard guides the user through the initial configuration of the system, blocking until
+// it's been completed, skipped or left untouched for setupWizardTimeout. The resulting network,
+// applications and Incus configuration is provided to the seed package and saved to the local seed
+// partition, so it's then handled exactly like seed data. This must not be called from a TUI event handler.
+func (t *TUI) RunSetupWizard(ctx context.Context) error {
+	// Start from the default configuration, acquiring addresses on every interface.
+	defaultCfg, err := seed.GetNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := []string{"all (DHCP)"}
+	for _, iface := range defaultCfg.Interfaces {
+		names = append(names, iface.Name)
+	}
+
+	choices := setupChoices{application: setupApplications[0], incusDefaults: true}
+	result := make(chan bool, 1)
+
+	var once sync.Once
+
+	finish := func(apply bool) {
+		once.Do(func() {
+			t.pages.RemovePage("wizard-error")
+			t.closeDialog()
+			result <- apply
+		})
+	}
+
+	// Continue with the defaults if nobody is at the console.
+	timer := time.AfterFunc(setupWizardTimeout, func() {
+		t.queueUpdate(func() { finish(false) })
+	})
+	defer timer.Stop()
+
+	form := tview.NewForm()
+
+	// Report invalid values on top of the wizard, returning to it once acknowledged.
+	showError := func(msg string) {
+		modal := tview.NewModal().
+			SetText(tview.Escape(msg)).
+			AddButtons([]string{"Close"}).
+			SetDoneFunc(func(_ int, _ string) {
+				t.pages.RemovePage("wizard-error")
+				t.app.SetFocus(form)
+			})
+		modal.SetTitle(" Invalid configuration ")
+
+		t.pages.AddPage("wizard-error", modal, true, true)
+		t.app.SetFocus(modal)
+	}
+
+	form.AddInputField("Hostname", "", 40, nil, func(text string) {
+		choices.hostname = text
+	})
+
+	form.AddDropDown("Interface", names, 0, func(name string, index int) {
+		choices.network.iface = ""
+		if index > 0 {
+			choices.network.iface = name
+		}
+	})
+
+	form.AddInputField("Addresses", "", 40, nil, func(text string) {
+		choices.network.addresses = text
+	})
+
+	form.AddInputField("Gateway", "", 40, nil, func(text string) {
+		choices.network.gateway = text
+	})
+
+	form.AddInputField("DNS servers", "", 40, nil, func(text string) {
+		choices.network.dns = text
+	})
+
+	form.AddDropDown("Application", setupApplications, 0, func(name string, _ int) {
+		choices.application = name
+	})
+
+	form.AddCheckbox("Incus defaults", true, func(checked bool) {
+		choices.incusDefaults = checked
+	})
+
+	form.AddButton("Continue", func() {
+		_, _, _, err := buildSetupSeeds(defaultCfg, choices)
+		if err != nil {
+			showError(err.Error())
+
+			return
+		}
+
+		finish(true)
+	})
+
+	form.AddButton("Skip", func() { finish(false) })
+	form.SetCancelFunc(func() { finish(false) })
+	form.SetTitle(" Initial setup ").SetBorder(true)
+
+	// Any input postpones the automatic continuation.
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		timer.Reset(setupWizardTimeout)
+
+		return event
+	})
+
+	err = t.openDialog(form, 64, 19)
+	if err != nil {
+		return err
+	}
+
+	var apply bool
+
+	select {
+	case <-ctx.Done():
+		// The application is stopped along with the context, so don't queue anything for it.
+		return ctx.Err()
+	case apply = <-result:
+	}
+
+	if !apply {
+		slog.InfoContext(ctx, "Setup wizard skipped, using the default configuration")
+
+		return nil
+	}
+
+	network, applications, incus, err := buildSetupSeeds(defaultCfg, choices)
+	if err != nil {
+		return err
+	}
+
+	err = seed.Provide("network", network)
+	if err != nil {
+		return err
+	}
+
+	err = seed.Provide("applications", applications)
+	if err != nil {
+		return err
+	}
+
+	seeds := []string{"network", "applications"}
+
+	if incus != nil {
+		err = seed.Provide("incus", incus)
+		if err != nil {
+			return err
+		}
+
+		seeds = append(seeds, "incus")
+	}
+
+	// Keep the configuration around should the system reboot before it's fully applied.
+	for _, name := range seeds {
+		err = seed.Persist(ctx, name)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to save the setup wizard configuration, it only applies to the current boot", "seed", name, "err", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "Initial configuration provided through the setup wizard", "application", choices.application)
+
+	return nil
+}
+
+// buildSetupSeeds validates the values entered in the setup wizard, returning the seed
+// configuration they translate to. No Incus seed is returned unless Incus was selected.
+func buildSetupSeeds(defaultCfg *api.SystemNetworkConfig, choices setupChoices) (*apiseed.Network, *apiseed.Applications, *apiseed.Incus, error) {
+	hostname := strings.TrimSpace(choices.hostname)
+	if strings.ContainsAny(hostname, " \t/") {
+		return nil, nil, nil, errors.New("invalid hostname '" + hostname + "'")
+	}
+
+	cfg := defaultCfg
+
+	// Only a selected interface gets a static configuration, others keep using DHCP.
+	if choices.network.iface != "" {
+		var err error
+
+		cfg, err = buildNetworkConfig(defaultCfg, choices.network)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	network := &apiseed.Network{SystemNetworkConfig: *cfg}
+
+	if hostname != "" {
+		dns := api.SystemNetworkDNS{}
+		if network.DNS != nil {
+			dns = *network.DNS
+		}
+
+		dns.Hostname = hostname
+		network.DNS = &dns
+	}
+
+	applications := &apiseed.Applications{
+		Applications: []apiseed.Application{{Name: choices.application}},
+	}
+
+	if choices.application != "incus" {
+		return network, applications, nil, nil
+	}
+
+	return network, applications, &apiseed.Incus{ApplyDefaults: choices.incusDefaults}, nil
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	ocapi "github.com/FuturFusion/operations-center/shared/api"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 
 	"github.com/lxc/incus-os/incus-osd/internal/applications"
 	"github.com/lxc/incus-os/incus-osd/internal/providers"
@@ -18,6 +20,10 @@ import (
 	"github.com/lxc/incus-os/incus-osd/internal/tui"
 )
 
+// signatureFailureVersion is the last OS update version which failed signature verification.
+// It is only accessed while holding the state's UpdateMutex.
+var signatureFailureVersion string
+
 // Checker utilizes the given provider to check for Secure Boot, OS, and application updates.
 func Checker(ctx context.Context, s *state.State, p providers.Provider, isStartupCheck bool, isUserRequested bool) { //nolint:revive
 	t, err := tui.GetTUI(nil)
@@ -469,7 +475,7 @@ func applyUpdate(ctx context.Context, s *state.State, t *tui.TUI, update provide
 	}
 
 	// Download the update.
-	err := update.Download(ctx, targetPath, updateModal.UpdateProgress)
+	err := update.Download(ctx, targetPath, updateModal.UpdateTransferProgress)
 	if err != nil {
 		return "", err
 	}
@@ -524,16 +530,52 @@ func applyUpdate(ctx context.Context, s *state.State, t *tui.TUI, update provide
 		// Update state once all SecureBoot keys are updated.
 		s.SecureBoot.Version = update.Version()
 		s.SecureBoot.FullyApplied = true
+		s.AddEvent(state.EventTypeUpdate, "Applied Secure Boot certificate update "+update.Version())
 	case providers.OSUpdate:
 		// Apply the update and reboot if first time through loop, otherwise wait for user to reboot system.
 		slog.InfoContext(ctx, "Applying OS update", "version", update.Version())
 		updateModal.Update("Applying " + s.OS.Name + " update version " + update.Version())
 
+		t.SetStatusBanner("Updating "+s.OS.Name+" — do not power off", tcell.ColorOrange)
+		defer t.SetStatusBanner("", tcell.ColorDefault)
+
 		err = systemd.ApplySystemUpdate(ctx, update.Version())
+		if errors.Is(err, systemd.ErrImageSignature) {
+			updateModal.Done()
+
+			s.System.Update.State.ImageSignature = "failed"
+			_ = s.Save()
+
+			// Only raise the alert once per version, rather than on every periodic check.
+			if signatureFailureVersion != update.Version() {
+				signatureFailureVersion = update.Version()
+
+				t.AddSecurityEvent("Signature verification failed for " + s.OS.Name + " update " + update.Version())
+			}
+
+			alertModal := t.GetModal("image-signature")
+
+			if alertModal == nil {
+				alertModal = t.AddModal(s.OS.Name+" Image Signature", "image-signature")
+			}
+
+			alertModal.Update("[red]Image signature: verification failed[white] for " + s.OS.Name + " update " + update.Version() + ", the update has not been applied: " + tview.Escape(err.Error()))
+		}
+
 		if err != nil {
 			return "", err
 		}
 
+		signatureFailureVersion = ""
+
+		alertModal := t.GetModal("image-signature")
+		if alertModal != nil {
+			alertModal.Done()
+		}
+
+		s.System.Update.State.ImageSignature = "verified"
+		updateModal.Update("Applied " + s.OS.Name + " update version " + update.Version() + " ([green]image signature: verified[white])")
+
 		// Record the new release.
 		if !s.System.Update.Config.AutoReboot && !isStartupCheck {
 			// Mark the system as needing a reboot down the line.
@@ -541,6 +583,7 @@ func applyUpdate(ctx context.Context, s *state.State, t *tui.TUI, update provide
 		}
 
 		s.OS.NextRelease = update.Version()
+		s.AddEvent(state.EventTypeUpdate, "Applied "+s.OS.Name+" update "+update.Version())
 		_ = s.Save()
 
 		// Record the state of auto-unlocked LUKS devices. With some TPMs this can be slow, so cache the
@@ -564,6 +607,8 @@ func applyUpdate(ctx context.Context, s *state.State, t *tui.TUI, update provide
 				return "", err
 			}
 
+			updateModal.Update("Rebooting to finalize " + s.OS.Name + " update to version " + update.Version())
+
 			// Rather than closing s.TriggerReboot, explicitly reboot here. This is needed when
 			// applying an update via the recovery mechanism since at that point in startup
 			// the channels won't be available yet.
@@ -616,6 +661,12 @@ func applyUpdate(ctx context.Context, s *state.State, t *tui.TUI, update provide
 			app.SetVersions(app.Version(), av)
 		} else {
 			// Record newly installed application and save state to disk.
+			if app.IsInstalled() {
+				s.AddEvent(state.EventTypeApplication, "Updated application "+appName+" to "+update.Version())
+			} else {
+				s.AddEvent(state.EventTypeApplication, "Installed application "+appName+" "+update.Version())
+			}
+
 			app.SetVersions(update.Version(), nil)
 
 			// Notify the provider.